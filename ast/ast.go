@@ -2,6 +2,8 @@ package ast
 
 import (
 	"bytes"
+	"math/big"
+	"strconv"
 	"strings"
 	"github.com/ankalang/anka/token"
 )
@@ -75,6 +77,10 @@ type AssignStatement struct {
 	Index    *IndexExpression    
 	Property *PropertyExpression 
 	Value    Expression
+	// değişken ya da sabit ile başlayan bildirimler, düz atamalarda boş
+	Declaration *token.Token
+	// [ilk, ...kalan] = liste ya da {ad, yaş: y} = kişi
+	Pattern Pattern
 }
 
 func (as *AssignStatement) statementNode()       {}
@@ -82,8 +88,14 @@ func (as *AssignStatement) TokenLiteral() string { return as.Token.Literal }
 func (as *AssignStatement) String() string {
 	var out bytes.Buffer
 
+	if as.Declaration != nil {
+		out.WriteString(as.Declaration.Literal + " ")
+	}
+
 	if as.Name != nil {
 		out.WriteString(as.Name.String())
+	} else if as.Pattern != nil {
+		out.WriteString(as.Pattern.String())
 	} else if len(as.Names) > 0 {
 		out.WriteString(as.Names[0].String())
 		for i := 1; i < len(as.Names); i++ {
@@ -130,6 +142,8 @@ func (cs *ContinueStatement) String() string {
 type ReturnStatement struct {
 	Token       token.Token 
 	ReturnValue Expression
+	// dönülen değer kuyruk konumunda bir çağrıysa (bkz. MarkTailCalls)
+	Tail bool
 }
 
 func (rs *ReturnStatement) statementNode()       {}
@@ -194,6 +208,10 @@ func (i *Identifier) String() string       { return i.Value }
 type Parameter struct {
 	*Identifier
 	Default Expression
+	// f({ad, yaş}) gibi parametrelerde argüman bu kalıba göre açılır
+	Pattern Pattern
+	// f(a, ...diğerleri) kalan sıralı argümanları bir dizide toplar
+	Rest bool
 }
 
 func (p *Parameter) expressionNode()      {}
@@ -201,6 +219,14 @@ func (p *Parameter) TokenLiteral() string { return p.Token.Literal }
 func (p *Parameter) String() string {
 	s := p.Value
 
+	if p.Pattern != nil {
+		s = p.Pattern.String()
+	}
+
+	if p.Rest {
+		s = "..." + s
+	}
+
 	if p.Default != nil {
 		s += " = " + p.Default.String()
 	}
@@ -217,9 +243,11 @@ func (b *Boolean) expressionNode()      {}
 func (b *Boolean) TokenLiteral() string { return b.Token.Literal }
 func (b *Boolean) String() string       { return b.Token.Literal }
 
+// Integer tamsayı sabitlerinde kesin değeri tutar, ondalıklı sayılarda nil'dir
 type NumberLiteral struct {
-	Token token.Token
-	Value float64
+	Token   token.Token
+	Value   float64
+	Integer *big.Int
 }
 
 func (nl *NumberLiteral) expressionNode()      {}
@@ -292,6 +320,7 @@ type MethodExpression struct {
 	Object    Expression
 	Method    Expression
 	Arguments []Expression
+	Keywords  []*KeywordArgument
 	Optional  bool
 	Deferred
 }
@@ -306,6 +335,10 @@ func (me *MethodExpression) String() string {
 		args = append(args, a.String())
 	}
 
+	for _, k := range me.Keywords {
+		args = append(args, k.String())
+	}
+
 	out.WriteString(me.Object.String())
 	if me.Optional {
 		out.WriteString("?")
@@ -432,6 +465,40 @@ func (fe *ForExpression) String() string {
 	return out.String()
 }
 
+type TryExpression struct {
+	Token      token.Token
+	Block      *BlockStatement
+	Identifier string
+	Catch      *BlockStatement
+	Finally    *BlockStatement
+}
+
+func (te *TryExpression) expressionNode()      {}
+func (te *TryExpression) TokenLiteral() string { return te.Token.Literal }
+func (te *TryExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("try ")
+	out.WriteString(te.Block.String())
+
+	if te.Catch != nil {
+		out.WriteString(" catch ")
+
+		if te.Identifier != "" {
+			out.WriteString(te.Identifier + " ")
+		}
+
+		out.WriteString(te.Catch.String())
+	}
+
+	if te.Finally != nil {
+		out.WriteString(" finally ")
+		out.WriteString(te.Finally.String())
+	}
+
+	return out.String()
+}
+
 type CommandExpression struct {
 	Token token.Token 
 	Value string
@@ -485,6 +552,244 @@ func (dc *Decorator) String() string {
 	return dc.Expression.String()
 }
 
+// TypeStatement bir kullanıcı tipini tanımlar:
+//
+//	tip Kişi {
+//		ad
+//		yaş = 0
+//		f selamla() { dön "Merhaba " + bu.ad }
+//	}
+type TypeStatement struct {
+	Token   token.Token
+	Name    string
+	Fields  []*TypeField
+	Methods []*FunctionLiteral
+}
+
+type TypeField struct {
+	Name    string
+	Default Expression
+}
+
+func (ts *TypeStatement) statementNode()       {}
+func (ts *TypeStatement) TokenLiteral() string { return ts.Token.Literal }
+func (ts *TypeStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ts.TokenLiteral() + " " + ts.Name + " {\n")
+
+	for _, f := range ts.Fields {
+		out.WriteString(f.Name)
+
+		if f.Default != nil {
+			out.WriteString(" = " + f.Default.String())
+		}
+
+		out.WriteString("\n")
+	}
+
+	for _, m := range ts.Methods {
+		out.WriteString(m.String() + "\n")
+	}
+
+	out.WriteString("}")
+
+	return out.String()
+}
+
+// MatchExpression değeri sırayla kolların kalıplarıyla karşılaştırır ve
+// eşleşen ilk kolun değerini döner:
+//
+//	eşle x {
+//		0 => "sıfır"
+//		1..9 => "rakam"
+//		[ilk, ...kalan] => ilk
+//		{"ad": ad} => ad
+//		Yazı s eğer uzunluk(s) > 3 => s
+//		_ => "diğer"
+//	}
+type MatchExpression struct {
+	Token   token.Token
+	Subject Expression
+	Arms    []*MatchArm
+}
+
+type MatchArm struct {
+	Pattern Pattern
+	Guard   Expression
+	Body    *BlockStatement
+}
+
+func (me *MatchExpression) expressionNode()      {}
+func (me *MatchExpression) TokenLiteral() string { return me.Token.Literal }
+func (me *MatchExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(me.TokenLiteral() + " " + me.Subject.String() + " {\n")
+
+	for _, arm := range me.Arms {
+		out.WriteString(arm.Pattern.String())
+
+		if arm.Guard != nil {
+			out.WriteString(" eğer " + arm.Guard.String())
+		}
+
+		out.WriteString(" => " + arm.Body.String() + "\n")
+	}
+
+	out.WriteString("}")
+
+	return out.String()
+}
+
+// Pattern eşle kollarında kullanılan kalıplardır
+type Pattern interface {
+	Node
+	patternNode()
+}
+
+// WildcardPattern (_) her değerle eşleşir
+type WildcardPattern struct {
+	Token token.Token
+}
+
+func (wp *WildcardPattern) patternNode()         {}
+func (wp *WildcardPattern) TokenLiteral() string { return wp.Token.Literal }
+func (wp *WildcardPattern) String() string       { return "_" }
+
+// BindingPattern her değerle eşleşir ve değeri verilen ada bağlar
+type BindingPattern struct {
+	Token token.Token
+	Name  string
+}
+
+func (bp *BindingPattern) patternNode()         {}
+func (bp *BindingPattern) TokenLiteral() string { return bp.Token.Literal }
+func (bp *BindingPattern) String() string       { return bp.Name }
+
+// LiteralPattern sayı, yazı, mantıksal ya da null sabitiyle eşitliğe bakar
+type LiteralPattern struct {
+	Token token.Token
+	Value Expression
+}
+
+func (lp *LiteralPattern) patternNode()         {}
+func (lp *LiteralPattern) TokenLiteral() string { return lp.Token.Literal }
+func (lp *LiteralPattern) String() string       { return lp.Value.String() }
+
+// RangePattern 1..9 gibi iki ucu da dahil bir aralıktır
+type RangePattern struct {
+	Token token.Token
+	From  Expression
+	To    Expression
+}
+
+func (rp *RangePattern) patternNode()         {}
+func (rp *RangePattern) TokenLiteral() string { return rp.Token.Literal }
+func (rp *RangePattern) String() string       { return rp.From.String() + ".." + rp.To.String() }
+
+// TypePattern Sayı, Yazı ya da kullanıcı tipi gibi büyük harfle başlayan bir
+// tip adıdır; ardından gelen ad eşleşen değere bağlanır, Nokta {x, y} gibi
+// ardından gelen dizi ya da harita kalıbı değerin içine bakar
+type TypePattern struct {
+	Token    token.Token
+	TypeName string
+	Binding  string
+	Inner    Pattern
+}
+
+func (tp *TypePattern) patternNode()         {}
+func (tp *TypePattern) TokenLiteral() string { return tp.Token.Literal }
+func (tp *TypePattern) String() string {
+	if tp.Inner != nil {
+		return tp.TypeName + " " + tp.Inner.String()
+	}
+
+	if tp.Binding == "" {
+		return tp.TypeName
+	}
+
+	return tp.TypeName + " " + tp.Binding
+}
+
+// ArrayPattern [a, b] aynı uzunluktaki dizilerle, [a, ...kalan] en az bir
+// elemanlı dizilerle eşleşir
+type ArrayPattern struct {
+	Token    token.Token
+	Elements []Pattern
+	HasRest  bool
+	Rest     string
+}
+
+func (ap *ArrayPattern) patternNode()         {}
+func (ap *ArrayPattern) TokenLiteral() string { return ap.Token.Literal }
+func (ap *ArrayPattern) String() string {
+	elements := []string{}
+	for _, e := range ap.Elements {
+		elements = append(elements, e.String())
+	}
+
+	if ap.HasRest {
+		elements = append(elements, "..."+ap.Rest)
+	}
+
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+// HashPattern {"ad": ad, yaş: y, ...geri} verilen anahtarları içeren
+// haritalarla eşleşir, fazladan anahtarlar önemsenmez ya da geri'ye toplanır
+type HashPattern struct {
+	Token   token.Token
+	Keys    []string
+	Values  []Pattern
+	HasRest bool
+	Rest    string
+}
+
+func (hp *HashPattern) patternNode()         {}
+func (hp *HashPattern) TokenLiteral() string { return hp.Token.Literal }
+func (hp *HashPattern) String() string {
+	pairs := []string{}
+	for i, key := range hp.Keys {
+		pairs = append(pairs, strconv.Quote(key)+": "+hp.Values[i].String())
+	}
+
+	if hp.HasRest {
+		pairs = append(pairs, "..."+hp.Rest)
+	}
+
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// DefaultPattern {port = 80} ya da [a, b = 2] gibi değer eksik ya da null
+// olduğunda kullanılacak varsayılanı tutar
+type DefaultPattern struct {
+	Token   token.Token
+	Pattern Pattern
+	Default Expression
+}
+
+func (dp *DefaultPattern) patternNode()         {}
+func (dp *DefaultPattern) TokenLiteral() string { return dp.Token.Literal }
+func (dp *DefaultPattern) String() string       { return dp.Pattern.String() + " = " + dp.Default.String() }
+
+// AlternativePattern 1 | 2 | 3 seçeneklerden biri eşleştiğinde eşleşir
+type AlternativePattern struct {
+	Token   token.Token
+	Options []Pattern
+}
+
+func (ap *AlternativePattern) patternNode()         {}
+func (ap *AlternativePattern) TokenLiteral() string { return ap.Token.Literal }
+func (ap *AlternativePattern) String() string {
+	options := []string{}
+	for _, o := range ap.Options {
+		options = append(options, o.String())
+	}
+
+	return strings.Join(options, " | ")
+}
+
 type CurrentArgsLiteral struct {
 	Token token.Token 
 }
@@ -499,9 +804,21 @@ type CallExpression struct {
 	Token     token.Token 
 	Function  Expression  
 	Arguments []Expression
+	Keywords  []*KeywordArgument
 	Deferred
 }
 
+// KeywordArgument bağlan(host: "x", port: 5432) çağrısındaki isimli argümandır
+type KeywordArgument struct {
+	Token token.Token
+	Name  string
+	Value Expression
+}
+
+func (ka *KeywordArgument) String() string {
+	return ka.Name + ": " + ka.Value.String()
+}
+
 func (ce *CallExpression) expressionNode()      {}
 func (ce *CallExpression) TokenLiteral() string { return ce.Token.Literal }
 func (ce *CallExpression) String() string {
@@ -512,6 +829,10 @@ func (ce *CallExpression) String() string {
 		args = append(args, a.String())
 	}
 
+	for _, k := range ce.Keywords {
+		args = append(args, k.String())
+	}
+
 	out.WriteString(ce.Function.String())
 	out.WriteString("(")
 	out.WriteString(strings.Join(args, ", "))
@@ -632,6 +953,7 @@ func (pe *PropertyExpression) String() string {
 
 type HashLiteral struct {
 	Token token.Token 
+	Keys  []Expression
 	Pairs map[Expression]Expression
 }
 
@@ -641,8 +963,8 @@ func (hl *HashLiteral) String() string {
 	var out bytes.Buffer
 
 	pairs := []string{}
-	for key, value := range hl.Pairs {
-		pairs = append(pairs, key.String()+":"+value.String())
+	for _, key := range hl.Keys {
+		pairs = append(pairs, key.String()+":"+hl.Pairs[key].String())
 	}
 
 	out.WriteString("{")
@@ -0,0 +1,50 @@
+package ast
+
+// MarkTailCalls fonksiyon gövdesinde bir çağrıyı dönen dön ifadelerini
+// işaretler; bu çağrılar fonksiyonun çerçevesi bırakıldıktan sonra yapılabilir.
+// Dene blokları ve ertelenmiş ifadesi olan bloklar fonksiyon dönerken hâlâ iş
+// yaptığından içlerindeki dön ifadeleri işaretlenmez.
+func MarkTailCalls(body *BlockStatement) {
+	if body == nil {
+		return
+	}
+
+	for _, s := range body.Statements {
+		if es, ok := s.(*ExpressionStatement); ok {
+			if d, ok := es.Expression.(Deferrable); ok && d.IsDeferred() {
+				return
+			}
+		}
+	}
+
+	for _, s := range body.Statements {
+		switch s := s.(type) {
+		case *ReturnStatement:
+			if call, ok := s.ReturnValue.(*CallExpression); ok && !call.IsDeferred() {
+				s.Tail = true
+			}
+		case *ExpressionStatement:
+			markTailExpression(s.Expression)
+		}
+	}
+}
+
+func markTailExpression(exp Expression) {
+	switch e := exp.(type) {
+	case *IfExpression:
+		for _, scenario := range e.Scenarios {
+			MarkTailCalls(scenario.Consequence)
+		}
+	case *WhileExpression:
+		MarkTailCalls(e.Consequence)
+	case *ForExpression:
+		MarkTailCalls(e.Block)
+	case *ForInExpression:
+		MarkTailCalls(e.Block)
+		MarkTailCalls(e.Alternative)
+	case *MatchExpression:
+		for _, arm := range e.Arms {
+			MarkTailCalls(arm.Body)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package ast
+
+// Inspect ağacı derinlik öncelikli dolaşır ve her düğüm için f'i çağırır;
+// f false dönerse o düğümün çocuklarına inilmez
+func Inspect(node Node, f func(Node) bool) {
+	if node == nil || !f(node) {
+		return
+	}
+
+	switch n := node.(type) {
+	case *Program:
+		for _, s := range n.Statements {
+			Inspect(s, f)
+		}
+	case *BlockStatement:
+		for _, s := range n.Statements {
+			Inspect(s, f)
+		}
+	case *ExpressionStatement:
+		inspectExpression(n.Expression, f)
+	case *ReturnStatement:
+		inspectExpression(n.ReturnValue, f)
+	case *AssignStatement:
+		if n.Name != nil {
+			Inspect(n.Name, f)
+		}
+
+		for _, name := range n.Names {
+			inspectExpression(name, f)
+		}
+
+		if n.Index != nil {
+			Inspect(n.Index, f)
+		}
+
+		if n.Property != nil {
+			Inspect(n.Property, f)
+		}
+
+		inspectPattern(n.Pattern, f)
+		inspectExpression(n.Value, f)
+	case *TypeStatement:
+		for _, field := range n.Fields {
+			inspectExpression(field.Default, f)
+		}
+
+		for _, m := range n.Methods {
+			Inspect(m, f)
+		}
+	case *PrefixExpression:
+		inspectExpression(n.Right, f)
+	case *InfixExpression:
+		inspectExpression(n.Left, f)
+		inspectExpression(n.Right, f)
+	case *CompoundAssignment:
+		inspectExpression(n.Left, f)
+		inspectExpression(n.Right, f)
+	case *IfExpression:
+		for _, s := range n.Scenarios {
+			inspectExpression(s.Condition, f)
+			inspectBlock(s.Consequence, f)
+		}
+	case *WhileExpression:
+		inspectExpression(n.Condition, f)
+		inspectBlock(n.Consequence, f)
+	case *ForExpression:
+		inspectStatement(n.Starter, f)
+		inspectExpression(n.Condition, f)
+		inspectStatement(n.Closer, f)
+		inspectBlock(n.Block, f)
+	case *ForInExpression:
+		inspectExpression(n.Iterable, f)
+		inspectBlock(n.Block, f)
+		inspectBlock(n.Alternative, f)
+	case *TryExpression:
+		inspectBlock(n.Block, f)
+		inspectBlock(n.Catch, f)
+		inspectBlock(n.Finally, f)
+	case *MatchExpression:
+		inspectExpression(n.Subject, f)
+
+		for _, arm := range n.Arms {
+			inspectPattern(arm.Pattern, f)
+			inspectExpression(arm.Guard, f)
+			inspectBlock(arm.Body, f)
+		}
+	case *FunctionLiteral:
+		for _, p := range n.Parameters {
+			Inspect(p, f)
+		}
+
+		inspectBlock(n.Body, f)
+	case *Parameter:
+		inspectPattern(n.Pattern, f)
+		inspectExpression(n.Default, f)
+	case *Decorator:
+		inspectExpression(n.Expression, f)
+		inspectExpression(n.Decorated, f)
+	case *CallExpression:
+		inspectExpression(n.Function, f)
+
+		for _, a := range n.Arguments {
+			inspectExpression(a, f)
+		}
+
+		for _, k := range n.Keywords {
+			inspectExpression(k.Value, f)
+		}
+	case *MethodExpression:
+		inspectExpression(n.Object, f)
+		inspectExpression(n.Method, f)
+
+		for _, a := range n.Arguments {
+			inspectExpression(a, f)
+		}
+
+		for _, k := range n.Keywords {
+			inspectExpression(k.Value, f)
+		}
+	case *PropertyExpression:
+		inspectExpression(n.Object, f)
+		inspectExpression(n.Property, f)
+	case *IndexExpression:
+		inspectExpression(n.Left, f)
+		inspectExpression(n.Index, f)
+		inspectExpression(n.End, f)
+	case *ArrayLiteral:
+		for _, e := range n.Elements {
+			inspectExpression(e, f)
+		}
+	case *HashLiteral:
+		for _, key := range n.Keys {
+			inspectExpression(key, f)
+			inspectExpression(n.Pairs[key], f)
+		}
+	case *LiteralPattern:
+		inspectExpression(n.Value, f)
+	case *RangePattern:
+		inspectExpression(n.From, f)
+		inspectExpression(n.To, f)
+	case *TypePattern:
+		inspectPattern(n.Inner, f)
+	case *DefaultPattern:
+		inspectPattern(n.Pattern, f)
+		inspectExpression(n.Default, f)
+	case *ArrayPattern:
+		for _, e := range n.Elements {
+			inspectPattern(e, f)
+		}
+	case *HashPattern:
+		for _, v := range n.Values {
+			inspectPattern(v, f)
+		}
+	case *AlternativePattern:
+		for _, o := range n.Options {
+			inspectPattern(o, f)
+		}
+	}
+}
+
+// arayüz alanlarındaki nil değerler düğüm olarak dolaşılmaz
+
+func inspectExpression(e Expression, f func(Node) bool) {
+	if e != nil {
+		Inspect(e, f)
+	}
+}
+
+func inspectStatement(s Statement, f func(Node) bool) {
+	if s != nil {
+		Inspect(s, f)
+	}
+}
+
+func inspectPattern(p Pattern, f func(Node) bool) {
+	if p != nil {
+		Inspect(p, f)
+	}
+}
+
+func inspectBlock(b *BlockStatement, f func(Node) bool) {
+	if b != nil {
+		Inspect(b, f)
+	}
+}
@@ -0,0 +1,271 @@
+package code
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// Instructions sanal makinenin (vm) çalıştırdığı bayt kodudur
+type Instructions []byte
+
+type Opcode byte
+
+// Komutların açıklamalarında "ad" derleyicinin ad tablosundaki, "simge" ise
+// fonksiyonun simge tablosundaki bir sırayı gösterir; simgeler hata konumları
+// ve sonuç nesneleri için kullanılır.
+const (
+	// OpConstant sabit havuzundaki değeri yığına koyar
+	OpConstant Opcode = iota
+	OpNull
+	OpTrue
+	OpFalse
+	OpPop
+
+	// OpGetGlobal adı ortamda, yoksa yerleşik fonksiyonlarda arar
+	OpGetGlobal
+	OpSetGlobal
+	OpDeclareGlobal
+
+	// yerel, hücre ve serbest değişken okumaları değer henüz atanmamışsa
+	// aynı adla ortama bakar; ağaç yorumlayıcısında ad o anda tanımsızdır
+	OpGetLocal
+	OpSetLocal
+	OpGetCell
+	OpSetCell
+	OpGetFree
+	OpSetFree
+	// OpGetSoft yazı ara değerlemesindeki adları okur, bulunamazsa boş yazı koyar
+	OpGetSoft
+
+	// OpBox parametrenin değerini yeni bir hücreye taşır
+	OpBox
+	// OpEnterBlock blok kapsamındaki yerelleri temizler ve hücreleri yeniler
+	OpEnterBlock
+	OpClosure
+
+	OpCall
+	// OpTailCall aynı makinedeki bir kapanışı çağıranın çerçevesinde çalıştırır;
+	// diğer çağrılarda OpCall gibidir ve ardından OpReturnValue gelir
+	OpTailCall
+	OpMethod
+	OpReturnValue
+
+	OpJump
+	OpJumpNotTruthy
+	// OpJumpTruthy ve OpJumpFalsy atladıklarında değeri yığında bırakır
+	OpJumpTruthy
+	OpJumpFalsy
+
+	OpPrefix
+	OpInfix
+
+	OpArray
+	OpHash
+	OpIndex
+	OpSlice
+	OpSetIndex
+	OpProperty
+	OpSetProperty
+
+	OpInterpolate
+	OpCommand
+
+	// OpDefault parametre verilmişse varsayılan değerin kodunu atlar
+	OpDefault
+
+	OpIterStart
+	OpIterNext
+	OpIterEmpty
+	OpIterEnd
+
+	OpTry
+	OpEndTry
+	OpRaise
+	OpErrorHash
+	// OpFail verilen mesajla bir hata oluşturur
+	OpFail
+
+	// OpEval derlenemeyen bir dosya düzeyi ifadeyi ağaç yorumlayıcısına bırakır
+	OpEval
+)
+
+type Definition struct {
+	Name          string
+	OperandWidths []int
+}
+
+var definitions = map[Opcode]*Definition{
+	OpConstant: {"OpConstant", []int{2}},
+	OpNull:     {"OpNull", []int{}},
+	OpTrue:     {"OpTrue", []int{}},
+	OpFalse:    {"OpFalse", []int{}},
+	OpPop:      {"OpPop", []int{}},
+
+	// ad, simge
+	OpGetGlobal: {"OpGetGlobal", []int{2, 2}},
+	OpSetGlobal: {"OpSetGlobal", []int{2, 2}},
+	// ad, simge, sabit mi
+	OpDeclareGlobal: {"OpDeclareGlobal", []int{2, 2, 1}},
+
+	// sıra, ad, simge
+	OpGetLocal: {"OpGetLocal", []int{2, 2, 2}},
+	OpSetLocal: {"OpSetLocal", []int{2}},
+	OpGetCell:  {"OpGetCell", []int{2, 2, 2}},
+	OpSetCell:  {"OpSetCell", []int{2}},
+	OpGetFree:  {"OpGetFree", []int{2, 2, 2}},
+	OpSetFree:  {"OpSetFree", []int{2}},
+	// kapsam, sıra, ad
+	OpGetSoft: {"OpGetSoft", []int{1, 2, 2}},
+
+	// yerel sıra, hücre sırası
+	OpBox: {"OpBox", []int{2, 2}},
+	// ilk yerel, yerel sayısı, ilk hücre, hücre sayısı
+	OpEnterBlock: {"OpEnterBlock", []int{2, 2, 2, 2}},
+	// fonksiyon sırası
+	OpClosure: {"OpClosure", []int{2}},
+
+	// argüman sayısı, isimli argüman kümesi (0: yok), simge
+	OpCall:     {"OpCall", []int{2, 2, 2}},
+	OpTailCall: {"OpTailCall", []int{2, 2, 2}},
+	// ad, argüman sayısı, isimli argüman kümesi, simge, isteğe bağlı mı
+	OpMethod:      {"OpMethod", []int{2, 2, 2, 2, 1}},
+	OpReturnValue: {"OpReturnValue", []int{}},
+
+	OpJump:          {"OpJump", []int{4}},
+	OpJumpNotTruthy: {"OpJumpNotTruthy", []int{4}},
+	OpJumpTruthy:    {"OpJumpTruthy", []int{4}},
+	OpJumpFalsy:     {"OpJumpFalsy", []int{4}},
+
+	// operatör adı, simge
+	OpPrefix: {"OpPrefix", []int{2, 2}},
+	OpInfix:  {"OpInfix", []int{2, 2}},
+
+	// eleman sayısı, simge
+	OpArray: {"OpArray", []int{2, 2}},
+	OpHash:  {"OpHash", []int{2, 2}},
+
+	OpIndex:    {"OpIndex", []int{2}},
+	OpSlice:    {"OpSlice", []int{2}},
+	OpSetIndex: {"OpSetIndex", []int{2}},
+	// ad, simge, isteğe bağlı mı
+	OpProperty: {"OpProperty", []int{2, 2, 1}},
+	// ad, simge
+	OpSetProperty: {"OpSetProperty", []int{2, 2}},
+
+	// parça sayısı, simge
+	OpInterpolate: {"OpInterpolate", []int{2, 2}},
+	OpCommand:     {"OpCommand", []int{2}},
+
+	// yerel sıra, atlanacak adres
+	OpDefault: {"OpDefault", []int{2, 4}},
+
+	OpIterStart: {"OpIterStart", []int{2}},
+	OpIterNext:  {"OpIterNext", []int{4}},
+	OpIterEmpty: {"OpIterEmpty", []int{}},
+	OpIterEnd:   {"OpIterEnd", []int{}},
+
+	OpTry:       {"OpTry", []int{4}},
+	OpEndTry:    {"OpEndTry", []int{}},
+	OpRaise:     {"OpRaise", []int{}},
+	OpErrorHash: {"OpErrorHash", []int{}},
+	// mesaj (ad tablosunda), simge
+	OpFail: {"OpFail", []int{2, 2}},
+
+	OpEval: {"OpEval", []int{2}},
+}
+
+func Lookup(op byte) (*Definition, error) {
+	def, ok := definitions[Opcode(op)]
+	if !ok {
+		return nil, fmt.Errorf("bilinmeyen komut: %d", op)
+	}
+
+	return def, nil
+}
+
+// Make komutu işlenenleriyle birlikte kodlar
+func Make(op Opcode, operands ...int) []byte {
+	def, ok := definitions[op]
+	if !ok {
+		return []byte{}
+	}
+
+	length := 1
+	for _, w := range def.OperandWidths {
+		length += w
+	}
+
+	instruction := make([]byte, length)
+	instruction[0] = byte(op)
+
+	offset := 1
+	for i, o := range operands {
+		width := def.OperandWidths[i]
+		switch width {
+		case 4:
+			binary.BigEndian.PutUint32(instruction[offset:], uint32(o))
+		case 2:
+			binary.BigEndian.PutUint16(instruction[offset:], uint16(o))
+		case 1:
+			instruction[offset] = byte(o)
+		}
+		offset += width
+	}
+
+	return instruction
+}
+
+// ReadOperands Make'in tersidir, okunan bayt sayısını da döner
+func ReadOperands(def *Definition, ins Instructions) ([]int, int) {
+	operands := make([]int, len(def.OperandWidths))
+	offset := 0
+
+	for i, width := range def.OperandWidths {
+		switch width {
+		case 4:
+			operands[i] = int(ReadUint32(ins[offset:]))
+		case 2:
+			operands[i] = int(ReadUint16(ins[offset:]))
+		case 1:
+			operands[i] = int(ins[offset])
+		}
+		offset += width
+	}
+
+	return operands, offset
+}
+
+func ReadUint16(ins Instructions) uint16 {
+	return binary.BigEndian.Uint16(ins)
+}
+
+func ReadUint32(ins Instructions) uint32 {
+	return binary.BigEndian.Uint32(ins)
+}
+
+// String komutları okunabilir biçimde listeler
+func (ins Instructions) String() string {
+	var out bytes.Buffer
+
+	i := 0
+	for i < len(ins) {
+		def, err := Lookup(ins[i])
+		if err != nil {
+			fmt.Fprintf(&out, "HATA: %s\n", err)
+			i++
+			continue
+		}
+
+		operands, read := ReadOperands(def, ins[i+1:])
+		fmt.Fprintf(&out, "%04d %s", i, def.Name)
+		for _, o := range operands {
+			fmt.Fprintf(&out, " %d", o)
+		}
+		out.WriteString("\n")
+
+		i += 1 + read
+	}
+
+	return out.String()
+}
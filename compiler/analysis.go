@@ -0,0 +1,239 @@
+package compiler
+
+import (
+	"github.com/ankalang/anka/ast"
+	"github.com/ankalang/anka/util"
+)
+
+// capturedNames iç fonksiyonlarda geçen adları toplar; dıştaki aynı adlı
+// değişkenler kapanışlarla paylaşılacağı için hücrede tutulur. Gölgelenen
+// adlar da toplanır, bu yalnızca gereğinden fazla hücre demektir.
+func capturedNames(nodes ...ast.Node) map[string]bool {
+	names := map[string]bool{}
+
+	for _, node := range nodes {
+		ast.Inspect(node, func(n ast.Node) bool {
+			if fl, ok := n.(*ast.FunctionLiteral); ok {
+				referencedNames(fl, names)
+				return false
+			}
+
+			return true
+		})
+	}
+
+	return names
+}
+
+// referencedNames düğümde okunan ya da atanan adları toplar
+func referencedNames(node ast.Node, names map[string]bool) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Identifier:
+			names[n.Value] = true
+		case *ast.StringLiteral:
+			addStringVars(n.Value, names)
+		case *ast.CommandExpression:
+			addStringVars(n.Value, names)
+		case *ast.PropertyExpression:
+			// h.ad içindeki ad bir değişken değildir
+			referencedNames(n.Object, names)
+			return false
+		case *ast.MethodExpression:
+			referencedNames(n.Object, names)
+
+			for _, a := range n.Arguments {
+				referencedNames(a, names)
+			}
+
+			for _, k := range n.Keywords {
+				referencedNames(k.Value, names)
+			}
+
+			return false
+		}
+
+		return true
+	})
+}
+
+func addStringVars(s string, names map[string]bool) {
+	_, vars := util.StringVars(s)
+
+	for _, name := range vars {
+		names[name] = true
+	}
+}
+
+// functionNames fonksiyon çağrıldığında fonksiyon kapsamında tanımlanabilecek
+// adları döner: gövdenin doğrudan bildirimleri ve adlı iç fonksiyonlar her
+// zaman yereldir, düz atamalar ise ad dışarıda görünmüyorsa yerel olur.
+func functionNames(fl *ast.FunctionLiteral) (assigned, declared []string) {
+	seen := map[string]bool{}
+	add := func(list *[]string, name string) {
+		if !seen[name] {
+			seen[name] = true
+			*list = append(*list, name)
+		}
+	}
+
+	for _, st := range fl.Body.Statements {
+		if as, ok := st.(*ast.AssignStatement); ok && as.Declaration != nil && as.Name != nil {
+			add(&declared, as.Name.Value)
+		}
+	}
+
+	visit := func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FunctionLiteral:
+			if n.Name != "" {
+				add(&declared, n.Name)
+			}
+
+			return false
+		case *ast.AssignStatement:
+			if n.Declaration == nil && n.Name != nil {
+				add(&assigned, n.Name.Value)
+			}
+		}
+
+		return true
+	}
+
+	for _, p := range fl.Parameters {
+		if p.Default != nil {
+			ast.Inspect(p.Default, visit)
+		}
+	}
+
+	ast.Inspect(fl.Body, visit)
+
+	// bildirilen adlar atananlardan önce yer alır
+	assignedOnly := assigned[:0]
+	for _, name := range assigned {
+		if !contains(declared, name) {
+			assignedOnly = append(assignedOnly, name)
+		}
+	}
+
+	return assignedOnly, declared
+}
+
+// globalNames dosya kapsamında, yani ortamda tanımlanacak adları döner;
+// fonksiyonlardaki atamalar bu adları yerel değil dosya değişkeni sayar
+func globalNames(program *ast.Program) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for _, st := range program.Statements {
+		if as, ok := st.(*ast.AssignStatement); ok && as.Declaration != nil {
+			if as.Name != nil {
+				add(as.Name.Value)
+			}
+
+			patternNames(as.Pattern, add)
+		}
+	}
+
+	ast.Inspect(program, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FunctionLiteral:
+			add(n.Name)
+			return false
+		case *ast.TypeStatement:
+			add(n.Name)
+			return false
+		case *ast.Decorator:
+			if fl, ok := n.Decorated.(*ast.FunctionLiteral); ok {
+				add(fl.Name)
+			}
+
+			return false
+		case *ast.AssignStatement:
+			if n.Declaration != nil {
+				return true
+			}
+
+			if n.Name != nil {
+				add(n.Name.Value)
+			}
+
+			for _, name := range n.Names {
+				add(name.String())
+			}
+
+			patternNames(n.Pattern, add)
+		}
+
+		return true
+	})
+
+	return names
+}
+
+// patternNames kalıbın bağladığı adları verir
+func patternNames(p ast.Pattern, add func(string)) {
+	if p == nil {
+		return
+	}
+
+	ast.Inspect(p, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.BindingPattern:
+			add(n.Name)
+		case *ast.TypePattern:
+			if n.Binding != "" {
+				add(n.Binding)
+			} else if n.Inner == nil {
+				add(n.TypeName)
+			}
+		case *ast.ArrayPattern:
+			add(n.Rest)
+		case *ast.HashPattern:
+			add(n.Rest)
+		}
+
+		return true
+	})
+}
+
+// hasControlFlow bloğun, iç fonksiyonlar dışında, dön, dur ya da devam
+// içerip içermediğine bakar
+func hasControlFlow(blocks ...*ast.BlockStatement) bool {
+	found := false
+
+	for _, b := range blocks {
+		if b == nil {
+			continue
+		}
+
+		ast.Inspect(b, func(n ast.Node) bool {
+			switch n.(type) {
+			case *ast.FunctionLiteral:
+				return false
+			case *ast.ReturnStatement, *ast.BreakStatement, *ast.ContinueStatement:
+				found = true
+			}
+
+			return !found
+		})
+	}
+
+	return found
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
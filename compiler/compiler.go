@@ -0,0 +1,753 @@
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ankalang/anka/ast"
+	"github.com/ankalang/anka/code"
+	"github.com/ankalang/anka/evaluator"
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+	"github.com/ankalang/anka/util"
+)
+
+// işlenenler iki bayta sığmalıdır
+const maxOperand = 1<<16 - 1
+
+var errTooLarge = errors.New("program bayt koduna çevrilemeyecek kadar büyük")
+
+// Bytecode derlenmiş programdır; Main dosya düzeyindeki kodu çalıştırır
+type Bytecode struct {
+	Main      *object.CompiledFunction
+	Functions []*object.CompiledFunction
+	Constants []object.Object
+	// ad tablosu: değişken adları, operatörler ve hata mesajları
+	Names []string
+	// ağaç yorumlayıcısına bırakılan dosya düzeyi ifadeler
+	Nodes []ast.Node
+	// program bittikten sonra çalıştırılacak ertelenmiş ifadeler
+	Deferred []ast.Node
+}
+
+type Compiler struct {
+	globals   map[string]bool
+	constants []object.Object
+	functions []*object.CompiledFunction
+	names     []string
+	nameIndex map[string]int
+	nodes     []ast.Node
+	deferred  []ast.Node
+	unit      *unit
+	tooLarge  bool
+}
+
+// unsupported derleyicinin bayt koduna çeviremediği bir yapıdır; bu yapıyı
+// içeren dosya düzeyi ifade ağaç yorumlayıcısıyla çalıştırılır
+type unsupported struct {
+	node ast.Node
+}
+
+func (e *unsupported) Error() string {
+	return fmt.Sprintf("derlenemeyen yapı: %T", e.node)
+}
+
+// New ortamda zaten tanımlı adlarla bir derleyici oluşturur
+func New(globals []string) *Compiler {
+	c := &Compiler{globals: map[string]bool{}, nameIndex: map[string]int{}}
+
+	for _, name := range globals {
+		c.globals[name] = true
+	}
+
+	return c
+}
+
+func (c *Compiler) Compile(program *ast.Program) (*Bytecode, error) {
+	for _, name := range globalNames(program) {
+		c.globals[name] = true
+	}
+
+	main := &object.CompiledFunction{}
+	c.unit = &unit{
+		fn:       main,
+		scopes:   []*scope{{symbols: map[string]*symbol{}, global: true}},
+		captured: capturedNames(program),
+	}
+
+	for _, statement := range program.Statements {
+		if isDeferred(statement) {
+			c.deferred = append(c.deferred, statement)
+			continue
+		}
+
+		if err := c.compileTopLevel(statement); err != nil {
+			return nil, err
+		}
+	}
+
+	c.emit(code.OpNull)
+	c.emit(code.OpReturnValue)
+
+	if c.tooLarge {
+		return nil, errTooLarge
+	}
+
+	return &Bytecode{
+		Main:      main,
+		Functions: c.functions,
+		Constants: c.constants,
+		Names:     c.names,
+		Nodes:     c.nodes,
+		Deferred:  c.deferred,
+	}, nil
+}
+
+// compileTopLevel derlenemeyen bir ifadenin ürettiği kodu geri alır ve yerine
+// ifadeyi ağaç yorumlayıcısına bırakan OpEval koyar
+func (c *Compiler) compileTopLevel(statement ast.Statement) error {
+	main := c.unit
+	instructions := len(main.fn.Instructions)
+	tokens := len(main.fn.Tokens)
+
+	err := c.compileStatement(statement)
+	if _, ok := err.(*unsupported); !ok {
+		return err
+	}
+
+	c.unit = main
+	main.fn.Instructions = main.fn.Instructions[:instructions]
+	main.fn.Tokens = main.fn.Tokens[:tokens]
+	main.scopes = main.scopes[:1]
+	main.locals, main.cells = 0, 0
+	main.loops, main.tries = nil, 0
+
+	c.nodes = append(c.nodes, statement)
+	c.emit(code.OpEval, c.operand(len(c.nodes)-1))
+	c.emit(code.OpPop)
+
+	return nil
+}
+
+func isDeferred(statement ast.Statement) bool {
+	x, ok := statement.(*ast.ExpressionStatement)
+	if !ok {
+		return false
+	}
+
+	d, ok := x.Expression.(ast.Deferrable)
+	return ok && d.IsDeferred()
+}
+
+func (c *Compiler) operand(i int) int {
+	if i > maxOperand {
+		c.tooLarge = true
+	}
+
+	return i
+}
+
+func (c *Compiler) emit(op code.Opcode, operands ...int) int {
+	fn := c.unit.fn
+	pos := len(fn.Instructions)
+	fn.Instructions = append(fn.Instructions, code.Make(op, operands...)...)
+
+	return pos
+}
+
+// replace pos'taki komutun işlenenlerini değiştirir
+func (c *Compiler) replace(pos int, operands ...int) {
+	fn := c.unit.fn
+	copy(fn.Instructions[pos:], code.Make(code.Opcode(fn.Instructions[pos]), operands...))
+}
+
+// patchJump pos'taki atlamayı şu anki konuma yönlendirir
+func (c *Compiler) patchJump(pos int) {
+	c.replace(pos, c.here())
+}
+
+func (c *Compiler) here() int {
+	return len(c.unit.fn.Instructions)
+}
+
+func (c *Compiler) token(tok token.Token) int {
+	fn := c.unit.fn
+	fn.Tokens = append(fn.Tokens, tok)
+
+	return c.operand(len(fn.Tokens) - 1)
+}
+
+func (c *Compiler) name(s string) int {
+	if i, ok := c.nameIndex[s]; ok {
+		return i
+	}
+
+	c.names = append(c.names, s)
+	c.nameIndex[s] = len(c.names) - 1
+
+	return c.operand(len(c.names) - 1)
+}
+
+func (c *Compiler) constant(obj object.Object) int {
+	c.constants = append(c.constants, obj)
+	return c.operand(len(c.constants) - 1)
+}
+
+func (c *Compiler) compileStatement(statement ast.Statement) error {
+	switch st := statement.(type) {
+	case *ast.ExpressionStatement:
+		if isDeferred(st) {
+			return &unsupported{st}
+		}
+
+		if err := c.compileExpression(st.Expression); err != nil {
+			return err
+		}
+
+		c.emit(code.OpPop)
+	case *ast.AssignStatement:
+		return c.compileAssignment(st)
+	case *ast.ReturnStatement:
+		var err error
+		if st.Tail {
+			err = c.compileCall(st.ReturnValue.(*ast.CallExpression), code.OpTailCall)
+		} else {
+			err = c.compileExpression(st.ReturnValue)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		c.emit(code.OpReturnValue)
+	default:
+		return &unsupported{statement}
+	}
+
+	return nil
+}
+
+// compileBlock bloğun son ifadesinin değerini yığında bırakır; bloktaki
+// bildirimler için gerekiyorsa yeni bir kapsam açar
+func (c *Compiler) compileBlock(block *ast.BlockStatement, newScope bool) error {
+	if newScope && declares(block) {
+		b := c.enterScope()
+		defer c.leaveScope(b)
+	}
+
+	return c.compileStatements(block)
+}
+
+func (c *Compiler) compileStatements(block *ast.BlockStatement) error {
+	if block == nil || len(block.Statements) == 0 {
+		c.emit(code.OpNull)
+		return nil
+	}
+
+	last := len(block.Statements) - 1
+	for i, statement := range block.Statements {
+		if i < last {
+			if err := c.compileStatement(statement); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if x, ok := statement.(*ast.ExpressionStatement); ok && !isDeferred(x) {
+			return c.compileExpression(x.Expression)
+		}
+
+		if err := c.compileStatement(statement); err != nil {
+			return err
+		}
+
+		c.emit(code.OpNull)
+	}
+
+	return nil
+}
+
+// declares bloğun kendi kapsamında bir ad tanımlayıp tanımlamadığına bakar
+func declares(block *ast.BlockStatement) bool {
+	if block == nil {
+		return false
+	}
+
+	for _, statement := range block.Statements {
+		if as, ok := statement.(*ast.AssignStatement); ok && as.Declaration != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+type blockScope struct {
+	enter  int
+	locals int
+	cells  int
+}
+
+func (c *Compiler) enterScope() *blockScope {
+	u := c.unit
+	u.scopes = append(u.scopes, newScope())
+
+	return &blockScope{enter: c.emit(code.OpEnterBlock, 0, 0, 0, 0), locals: u.locals, cells: u.cells}
+}
+
+func (c *Compiler) leaveScope(b *blockScope) {
+	u := c.unit
+	c.replace(b.enter, b.locals, u.locals-b.locals, b.cells, u.cells-b.cells)
+
+	u.locals, u.cells = b.locals, b.cells
+	u.scopes = u.scopes[:len(u.scopes)-1]
+}
+
+func (c *Compiler) currentScope() *scope {
+	return c.unit.scopes[len(c.unit.scopes)-1]
+}
+
+func (c *Compiler) define(name string) *symbol {
+	sym := c.unit.define(c.currentScope(), name)
+	c.operand(c.unit.fn.NumLocals)
+	c.operand(c.unit.fn.NumCells)
+
+	return sym
+}
+
+func (c *Compiler) resolve(node ast.Node, name string) (reference, error) {
+	ref, ok := resolve(c.unit, name)
+	if !ok {
+		return ref, &unsupported{node}
+	}
+
+	return ref, nil
+}
+
+func (c *Compiler) load(node ast.Node, name string, tok token.Token) error {
+	ref, err := c.resolve(node, name)
+	if err != nil {
+		return err
+	}
+
+	switch ref.kind {
+	case globalRef:
+		c.emit(code.OpGetGlobal, c.name(name), c.token(tok))
+	case localRef:
+		c.emit(code.OpGetLocal, ref.index, c.name(name), c.token(tok))
+	case cellRef:
+		c.emit(code.OpGetCell, ref.index, c.name(name), c.token(tok))
+	case freeRef:
+		c.emit(code.OpGetFree, c.operand(ref.index), c.name(name), c.token(tok))
+	}
+
+	return nil
+}
+
+// assign yığındaki değeri adın görünen tanımına yazar
+func (c *Compiler) assign(node ast.Node, name string, tok token.Token) error {
+	ref, err := c.resolve(node, name)
+	if err != nil {
+		return err
+	}
+
+	if ref.kind == globalRef {
+		c.emit(code.OpSetGlobal, c.name(name), c.token(tok))
+		return nil
+	}
+
+	if ref.symbol.constant {
+		c.fail(tok, "'%s' sabittir, değeri değiştirilemez", name)
+		return nil
+	}
+
+	c.store(ref)
+	return nil
+}
+
+func (c *Compiler) store(ref reference) {
+	switch ref.kind {
+	case localRef:
+		c.emit(code.OpSetLocal, ref.index)
+	case cellRef:
+		c.emit(code.OpSetCell, ref.index)
+	case freeRef:
+		c.emit(code.OpSetFree, ref.index)
+	}
+}
+
+// declare yığındaki değerle adı şu anki kapsamda tanımlar
+func (c *Compiler) declare(name string, tok token.Token, constant bool) {
+	c.declareIn(c.currentScope(), name, tok, constant)
+}
+
+func (c *Compiler) declareIn(s *scope, name string, tok token.Token, constant bool) {
+	if s.global {
+		flag := 0
+		if constant {
+			flag = 1
+		}
+
+		c.emit(code.OpDeclareGlobal, c.name(name), c.token(tok), flag)
+		return
+	}
+
+	sym, ok := s.symbols[name]
+	if !ok {
+		sym = c.unit.define(s, name)
+		c.operand(c.unit.fn.NumLocals)
+		c.operand(c.unit.fn.NumCells)
+	} else if sym.constant {
+		c.fail(tok, "'%s' sabittir, yeniden tanımlanamaz", name)
+		return
+	}
+
+	ref := reference{kind: localRef, index: sym.index, symbol: sym}
+	if sym.cell {
+		ref.kind = cellRef
+	}
+
+	c.store(ref)
+	sym.constant = sym.constant || constant
+}
+
+func (c *Compiler) fail(tok token.Token, format string, a ...interface{}) {
+	c.emit(code.OpFail, c.name(fmt.Sprintf(format, a...)), c.token(tok))
+}
+
+func (c *Compiler) compileAssignment(as *ast.AssignStatement) error {
+	if len(as.Names) > 0 || as.Pattern != nil {
+		return &unsupported{as}
+	}
+
+	if as.Value == nil {
+		c.emit(code.OpNull)
+	} else if err := c.compileExpression(as.Value); err != nil {
+		return err
+	}
+
+	switch {
+	case as.Name != nil:
+		if as.Declaration != nil {
+			c.declare(as.Name.Value, as.Token, as.Declaration.Type == token.CONST)
+			return nil
+		}
+
+		return c.assign(as, as.Name.Value, as.Token)
+	case as.Index != nil:
+		return c.compileSetIndex(as.Index)
+	case as.Property != nil:
+		return c.compileSetProperty(as.Property)
+	}
+
+	c.emit(code.OpPop)
+	return nil
+}
+
+func (c *Compiler) compileSetIndex(ie *ast.IndexExpression) error {
+	if err := c.compileExpression(ie.Left); err != nil {
+		return err
+	}
+
+	if err := c.compileExpression(ie.Index); err != nil {
+		return err
+	}
+
+	c.emit(code.OpSetIndex, c.token(ie.Token))
+	return nil
+}
+
+func (c *Compiler) compileSetProperty(pe *ast.PropertyExpression) error {
+	if err := c.compileExpression(pe.Object); err != nil {
+		return err
+	}
+
+	c.emit(code.OpSetProperty, c.name(pe.Property.String()), c.token(pe.Token))
+	return nil
+}
+
+func (c *Compiler) compileExpression(expression ast.Expression) error {
+	switch node := expression.(type) {
+	case nil:
+		c.emit(code.OpNull)
+	case *ast.Identifier:
+		return c.load(node, node.Value, node.Token)
+	case *ast.NumberLiteral:
+		c.emit(code.OpConstant, c.constant(evaluator.Eval(node, nil)))
+	case *ast.Boolean:
+		if node.Value {
+			c.emit(code.OpTrue)
+		} else {
+			c.emit(code.OpFalse)
+		}
+	case *ast.NullLiteral:
+		c.emit(code.OpNull)
+	case *ast.StringLiteral:
+		return c.compileInterpolation(node, node.Value, node.Token)
+	case *ast.CommandExpression:
+		if err := c.compileInterpolation(node, strings.Trim(node.Value, " "), node.Token); err != nil {
+			return err
+		}
+
+		c.emit(code.OpCommand, c.token(node.Token))
+	case *ast.PrefixExpression:
+		if err := c.compileExpression(node.Right); err != nil {
+			return err
+		}
+
+		c.emit(code.OpPrefix, c.name(node.Operator), c.token(node.Token))
+	case *ast.InfixExpression:
+		return c.compileInfix(node)
+	case *ast.CompoundAssignment:
+		return c.compileCompoundAssignment(node)
+	case *ast.IfExpression:
+		return c.compileIf(node)
+	case *ast.WhileExpression:
+		return c.compileWhile(node)
+	case *ast.ForExpression:
+		return c.compileFor(node)
+	case *ast.ForInExpression:
+		return c.compileForIn(node)
+	case *ast.TryExpression:
+		return c.compileTry(node)
+	case *ast.BreakStatement:
+		return c.compileBreak(node, true)
+	case *ast.ContinueStatement:
+		return c.compileBreak(node, false)
+	case *ast.FunctionLiteral:
+		return c.compileFunction(node)
+	case *ast.CallExpression:
+		return c.compileCall(node, code.OpCall)
+	case *ast.MethodExpression:
+		return c.compileMethod(node)
+	case *ast.PropertyExpression:
+		if err := c.compileExpression(node.Object); err != nil {
+			return err
+		}
+
+		optional := 0
+		if node.Optional {
+			optional = 1
+		}
+
+		c.emit(code.OpProperty, c.name(node.Property.String()), c.token(node.Token), optional)
+	case *ast.IndexExpression:
+		return c.compileIndex(node)
+	case *ast.ArrayLiteral:
+		if err := c.compileExpressions(node.Elements); err != nil {
+			return err
+		}
+
+		c.emit(code.OpArray, c.operand(len(node.Elements)), c.token(node.Token))
+	case *ast.HashLiteral:
+		for _, key := range node.Keys {
+			if err := c.compileExpression(key); err != nil {
+				return err
+			}
+
+			if err := c.compileExpression(node.Pairs[key]); err != nil {
+				return err
+			}
+		}
+
+		c.emit(code.OpHash, c.operand(len(node.Keys)), c.token(node.Token))
+	default:
+		return &unsupported{expression}
+	}
+
+	return nil
+}
+
+func (c *Compiler) compileExpressions(expressions []ast.Expression) error {
+	for _, e := range expressions {
+		if _, ok := e.(*ast.CurrentArgsLiteral); ok {
+			return &unsupported{e}
+		}
+
+		if err := c.compileExpression(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compileInterpolation yazıdaki $ad parçalarını çalışırken yerine koyar
+func (c *Compiler) compileInterpolation(node ast.Node, value string, tok token.Token) error {
+	parts, names := util.StringVars(value)
+	if len(names) == 0 {
+		c.emit(code.OpConstant, c.constant(&object.String{Token: tok, Value: parts[0]}))
+		return nil
+	}
+
+	for i, part := range parts {
+		c.emit(code.OpConstant, c.constant(&object.String{Token: tok, Value: part}))
+
+		if i == len(names) {
+			break
+		}
+
+		ref, err := c.resolve(node, names[i])
+		if err != nil {
+			return err
+		}
+
+		c.emit(code.OpGetSoft, ref.kind, c.operand(ref.index), c.name(names[i]))
+	}
+
+	c.emit(code.OpInterpolate, c.operand(len(parts)+len(names)), c.token(tok))
+	return nil
+}
+
+func (c *Compiler) compileInfix(node *ast.InfixExpression) error {
+	if err := c.compileExpression(node.Left); err != nil {
+		return err
+	}
+
+	var jump int
+	switch node.Operator {
+	case "&&":
+		jump = c.emit(code.OpJumpFalsy, 0)
+	case "||":
+		jump = c.emit(code.OpJumpTruthy, 0)
+	default:
+		if err := c.compileExpression(node.Right); err != nil {
+			return err
+		}
+
+		c.emit(code.OpInfix, c.name(node.Operator), c.token(node.Token))
+		return nil
+	}
+
+	if err := c.compileExpression(node.Right); err != nil {
+		return err
+	}
+
+	c.patchJump(jump)
+	return nil
+}
+
+func (c *Compiler) compileCompoundAssignment(node *ast.CompoundAssignment) error {
+	op := node.Operator
+	if len(op) >= 2 {
+		op = op[:len(op)-1]
+	}
+
+	switch left := node.Left.(type) {
+	case *ast.Identifier, *ast.IndexExpression, *ast.PropertyExpression:
+		if err := c.compileExpression(left); err != nil {
+			return err
+		}
+	default:
+		return &unsupported{node}
+	}
+
+	if err := c.compileExpression(node.Right); err != nil {
+		return err
+	}
+
+	c.emit(code.OpInfix, c.name(op), c.token(node.Token))
+
+	var err error
+	switch left := node.Left.(type) {
+	case *ast.Identifier:
+		err = c.assign(node, left.Value, node.Token)
+	case *ast.IndexExpression:
+		err = c.compileSetIndex(left)
+	case *ast.PropertyExpression:
+		err = c.compileSetProperty(left)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	c.emit(code.OpNull)
+	return nil
+}
+
+func (c *Compiler) compileIndex(node *ast.IndexExpression) error {
+	if err := c.compileExpression(node.Left); err != nil {
+		return err
+	}
+
+	if err := c.compileExpression(node.Index); err != nil {
+		return err
+	}
+
+	if !node.IsRange {
+		if node.End != nil {
+			return &unsupported{node}
+		}
+
+		c.emit(code.OpIndex, c.token(node.Token))
+		return nil
+	}
+
+	if err := c.compileExpression(node.End); err != nil {
+		return err
+	}
+
+	c.emit(code.OpSlice, c.token(node.Token))
+	return nil
+}
+
+func (c *Compiler) keywords(keywords []*ast.KeywordArgument) (int, error) {
+	if len(keywords) == 0 {
+		return 0, nil
+	}
+
+	for _, k := range keywords {
+		if err := c.compileExpression(k.Value); err != nil {
+			return 0, err
+		}
+	}
+
+	fn := c.unit.fn
+	fn.Keywords = append(fn.Keywords, keywords)
+
+	return c.operand(len(fn.Keywords)), nil
+}
+
+func (c *Compiler) compileCall(node *ast.CallExpression, op code.Opcode) error {
+	if err := c.compileExpression(node.Function); err != nil {
+		return err
+	}
+
+	if err := c.compileExpressions(node.Arguments); err != nil {
+		return err
+	}
+
+	kw, err := c.keywords(node.Keywords)
+	if err != nil {
+		return err
+	}
+
+	c.emit(op, c.operand(len(node.Arguments)), kw, c.token(node.Token))
+	return nil
+}
+
+func (c *Compiler) compileMethod(node *ast.MethodExpression) error {
+	if err := c.compileExpression(node.Object); err != nil {
+		return err
+	}
+
+	if err := c.compileExpressions(node.Arguments); err != nil {
+		return err
+	}
+
+	kw, err := c.keywords(node.Keywords)
+	if err != nil {
+		return err
+	}
+
+	optional := 0
+	if node.Optional {
+		optional = 1
+	}
+
+	c.emit(code.OpMethod, c.name(node.Method.String()), c.operand(len(node.Arguments)), kw, c.token(node.Token), optional)
+	return nil
+}
@@ -0,0 +1,416 @@
+package compiler
+
+import (
+	"github.com/ankalang/anka/ast"
+	"github.com/ankalang/anka/code"
+	"github.com/ankalang/anka/object"
+)
+
+func (c *Compiler) compileIf(node *ast.IfExpression) error {
+	ends := []int{}
+
+	for _, scenario := range node.Scenarios {
+		if err := c.compileExpression(scenario.Condition); err != nil {
+			return err
+		}
+
+		next := c.emit(code.OpJumpNotTruthy, 0)
+
+		if err := c.compileBlock(scenario.Consequence, true); err != nil {
+			return err
+		}
+
+		ends = append(ends, c.emit(code.OpJump, 0))
+		c.patchJump(next)
+	}
+
+	c.emit(code.OpNull)
+
+	for _, end := range ends {
+		c.patchJump(end)
+	}
+
+	return nil
+}
+
+func (c *Compiler) pushLoop() *loop {
+	l := &loop{tries: c.unit.tries}
+	c.unit.loops = append(c.unit.loops, l)
+
+	return l
+}
+
+// popLoop devam atlamalarını verilen adrese yönlendirir; dur atlamaları
+// döngünün çıkışında patchBreaks ile bağlanır
+func (c *Compiler) popLoop(l *loop, continueTarget int) {
+	for _, pos := range l.continues {
+		c.replace(pos, continueTarget)
+	}
+
+	c.unit.loops = c.unit.loops[:len(c.unit.loops)-1]
+}
+
+func (c *Compiler) patchBreaks(l *loop) {
+	for _, pos := range l.breaks {
+		c.patchJump(pos)
+	}
+}
+
+func (c *Compiler) compileBreak(node ast.Node, isBreak bool) error {
+	u := c.unit
+	l := u.currentLoop()
+	if l == nil {
+		return &unsupported{node}
+	}
+
+	// döngüden çıkarken aradaki dene bloklarının işleyicileri kaldırılır
+	for i := l.tries; i < u.tries; i++ {
+		c.emit(code.OpEndTry)
+	}
+
+	pos := c.emit(code.OpJump, 0)
+	if isBreak {
+		l.breaks = append(l.breaks, pos)
+	} else {
+		l.continues = append(l.continues, pos)
+	}
+
+	return nil
+}
+
+// compileBody döngü gövdesini değeri atılarak derler
+func (c *Compiler) compileBody(block *ast.BlockStatement) error {
+	if err := c.compileStatements(block); err != nil {
+		return err
+	}
+
+	c.emit(code.OpPop)
+	return nil
+}
+
+func (c *Compiler) compileWhile(node *ast.WhileExpression) error {
+	start := c.here()
+
+	if err := c.compileExpression(node.Condition); err != nil {
+		return err
+	}
+
+	exit := c.emit(code.OpJumpNotTruthy, 0)
+	l := c.pushLoop()
+
+	if declares(node.Consequence) {
+		b := c.enterScope()
+		if err := c.compileBody(node.Consequence); err != nil {
+			return err
+		}
+		c.leaveScope(b)
+	} else if err := c.compileBody(node.Consequence); err != nil {
+		return err
+	}
+
+	c.emit(code.OpJump, start)
+	c.popLoop(l, start)
+	c.patchJump(exit)
+	c.patchBreaks(l)
+	c.emit(code.OpNull)
+
+	return nil
+}
+
+func (c *Compiler) symbolRef(sym *symbol) reference {
+	if sym.cell {
+		return reference{kind: cellRef, index: sym.index, symbol: sym}
+	}
+
+	return reference{kind: localRef, index: sym.index, symbol: sym}
+}
+
+// compileFor döngü değişkenini döngünün kapsamında tutar; her tur gövde
+// değişkenin bir kopyasıyla çalışır ve kopya tur sonunda geri yazılır
+func (c *Compiler) compileFor(node *ast.ForExpression) error {
+	starter, ok := node.Starter.(*ast.AssignStatement)
+	if !ok {
+		return &unsupported{node}
+	}
+
+	loopScope := c.enterScope()
+
+	if err := c.compileExpression(starter.Value); err != nil {
+		return err
+	}
+
+	loopVar := c.symbolRef(c.define(node.Identifier))
+	c.store(loopVar)
+
+	start := c.here()
+	if err := c.compileExpression(node.Condition); err != nil {
+		return err
+	}
+
+	exit := c.emit(code.OpJumpNotTruthy, 0)
+	l := c.pushLoop()
+
+	iteration := c.enterScope()
+	if err := c.load(node, node.Identifier, node.Token); err != nil {
+		return err
+	}
+
+	iterationVar := c.symbolRef(c.define(node.Identifier))
+	c.store(iterationVar)
+
+	if err := c.compileBody(node.Block); err != nil {
+		return err
+	}
+
+	next := c.here()
+	if err := c.load(node, node.Identifier, node.Token); err != nil {
+		return err
+	}
+
+	c.store(loopVar)
+	c.leaveScope(iteration)
+
+	if err := c.compileStatement(node.Closer); err != nil {
+		return err
+	}
+
+	c.emit(code.OpJump, start)
+	c.popLoop(l, next)
+	c.patchJump(exit)
+	c.patchBreaks(l)
+	c.leaveScope(loopScope)
+	c.emit(code.OpNull)
+
+	return nil
+}
+
+func (c *Compiler) compileForIn(node *ast.ForInExpression) error {
+	if err := c.compileExpression(node.Iterable); err != nil {
+		return err
+	}
+
+	c.emit(code.OpIterStart, c.token(node.Token))
+
+	start := c.emit(code.OpIterNext, 0)
+	l := c.pushLoop()
+
+	// anahtar ve değer her turda yeni bir kapsamda tanımlanır
+	iteration := c.enterScope()
+	c.store(c.symbolRef(c.define(node.Value)))
+
+	if node.Key != "" {
+		c.store(c.symbolRef(c.define(node.Key)))
+	} else {
+		c.emit(code.OpPop)
+	}
+
+	if err := c.compileBody(node.Block); err != nil {
+		return err
+	}
+
+	c.leaveScope(iteration)
+	c.emit(code.OpJump, start)
+	c.popLoop(l, start)
+
+	// dolaşım bitti; hiç tur dönülmediyse varsa alternatif blok çalışır
+	c.patchJump(start)
+	if node.Alternative != nil {
+		c.emit(code.OpIterEmpty)
+		c.emit(code.OpIterEnd)
+		skip := c.emit(code.OpJumpNotTruthy, 0)
+
+		if err := c.compileStatements(node.Alternative); err != nil {
+			return err
+		}
+
+		end := c.emit(code.OpJump, 0)
+		c.patchJump(skip)
+		c.emit(code.OpNull)
+		c.patchJump(end)
+	} else {
+		c.emit(code.OpIterEnd)
+		c.emit(code.OpNull)
+	}
+
+	end := c.emit(code.OpJump, 0)
+
+	c.patchBreaks(l)
+	c.emit(code.OpIterEnd)
+	c.emit(code.OpNull)
+	c.patchJump(end)
+
+	return nil
+}
+
+// compileTry hata işleyicisini dene bloğunun başında kurar. Sonunda bloğu
+// olan bir dene, işleyiciden çıkan bir dön, dur ya da devam içeremez.
+func (c *Compiler) compileTry(node *ast.TryExpression) error {
+	u := c.unit
+	if node.Finally != nil && hasControlFlow(node.Block, node.Catch) {
+		return &unsupported{node}
+	}
+
+	u.tries++
+	handler := c.emit(code.OpTry, 0)
+
+	if err := c.compileBlock(node.Block, true); err != nil {
+		return err
+	}
+
+	u.tries--
+	c.emit(code.OpEndTry)
+	done := []int{c.emit(code.OpJump, 0)}
+
+	// işleyici çalıştığında hata yığının tepesindedir
+	c.patchJump(handler)
+
+	if node.Catch != nil {
+		var rethrow int
+		if node.Finally != nil {
+			u.tries++
+			rethrow = c.emit(code.OpTry, 0)
+		}
+
+		if err := c.compileCatch(node); err != nil {
+			return err
+		}
+
+		if node.Finally != nil {
+			u.tries--
+			c.emit(code.OpEndTry)
+		}
+
+		done = append(done, c.emit(code.OpJump, 0))
+
+		if node.Finally != nil {
+			c.patchJump(rethrow)
+		}
+	}
+
+	if node.Finally != nil {
+		if err := c.compileFinally(node.Finally); err != nil {
+			return err
+		}
+	}
+
+	if node.Catch == nil || node.Finally != nil {
+		c.emit(code.OpRaise)
+	}
+
+	for _, pos := range done {
+		c.patchJump(pos)
+	}
+
+	if node.Finally != nil {
+		return c.compileFinally(node.Finally)
+	}
+
+	return nil
+}
+
+func (c *Compiler) compileCatch(node *ast.TryExpression) error {
+	if node.Identifier == "" && !declares(node.Catch) {
+		c.emit(code.OpPop)
+		return c.compileStatements(node.Catch)
+	}
+
+	b := c.enterScope()
+	defer c.leaveScope(b)
+
+	if node.Identifier != "" {
+		c.emit(code.OpErrorHash)
+		c.store(c.symbolRef(c.define(node.Identifier)))
+	} else {
+		c.emit(code.OpPop)
+	}
+
+	return c.compileStatements(node.Catch)
+}
+
+func (c *Compiler) compileFinally(block *ast.BlockStatement) error {
+	if err := c.compileBlock(block, true); err != nil {
+		return err
+	}
+
+	c.emit(code.OpPop)
+	return nil
+}
+
+// compileFunction fonksiyonu ayrı bir birim olarak derler. Parametreler ilk
+// yuvalara yerleşir; gövdede tanımlanan adlar çağrının başında ayrılır.
+func (c *Compiler) compileFunction(fl *ast.FunctionLiteral) error {
+	outer := c.unit
+	fn := &object.CompiledFunction{Node: fl}
+
+	nodes := []ast.Node{fl.Body}
+	for _, p := range fl.Parameters {
+		if p.Pattern != nil {
+			return &unsupported{fl}
+		}
+
+		if p.Default != nil {
+			nodes = append(nodes, p.Default)
+		}
+	}
+
+	u := &unit{outer: outer, fn: fn, scopes: []*scope{newScope()}, captured: capturedNames(nodes...)}
+	s := u.scopes[0]
+	c.unit = u
+
+	u.locals = c.operand(len(fl.Parameters))
+	fn.NumLocals = u.locals
+
+	for i, p := range fl.Parameters {
+		if p.Default != nil {
+			skip := c.emit(code.OpDefault, i, 0)
+
+			if err := c.compileExpression(p.Default); err != nil {
+				return err
+			}
+
+			c.emit(code.OpSetLocal, i)
+			c.replace(skip, i, c.here())
+		}
+
+		sym := &symbol{index: i}
+		if u.captured[p.Value] {
+			sym = &symbol{index: u.cells, cell: true}
+			u.cells++
+			fn.NumCells = u.cells
+			c.emit(code.OpBox, i, sym.index)
+		}
+
+		s.symbols[p.Value] = sym
+	}
+
+	assigned, declared := functionNames(fl)
+	for _, name := range declared {
+		if _, ok := s.symbols[name]; !ok {
+			c.define(name)
+		}
+	}
+
+	for _, name := range assigned {
+		if _, ok := s.symbols[name]; !ok && !visible(outer, name, c.globals) {
+			c.define(name)
+		}
+	}
+
+	if err := c.compileStatements(fl.Body); err != nil {
+		return err
+	}
+
+	c.emit(code.OpReturnValue)
+	c.unit = outer
+
+	c.functions = append(c.functions, fn)
+	c.emit(code.OpClosure, c.operand(len(c.functions)-1))
+
+	if fl.Name == "" {
+		return nil
+	}
+
+	// adlı fonksiyonlar en yakın fonksiyon ya da dosya kapsamında tanımlanır
+	c.declareIn(outer.scopes[0], fl.Name, fl.Token, false)
+	return c.load(fl, fl.Name, fl.Token)
+}
@@ -0,0 +1,149 @@
+package compiler
+
+import (
+	"github.com/ankalang/anka/object"
+)
+
+// bir ad dört yerden birinde bulunur: ortamda (dosya değişkenleri), çerçevenin
+// yerel yuvalarında, çerçevenin hücrelerinde ya da kapanışın yakaladığı
+// hücrelerde
+const (
+	globalRef = iota
+	localRef
+	cellRef
+	freeRef
+)
+
+type reference struct {
+	kind   int
+	index  int
+	symbol *symbol
+}
+
+type symbol struct {
+	index    int
+	cell     bool
+	constant bool
+}
+
+// scope bir fonksiyon gövdesi ya da blok kapsamıdır; ana fonksiyonun en dış
+// kapsamı ortamın kendisidir ve ad tutmaz
+type scope struct {
+	symbols map[string]*symbol
+	global  bool
+}
+
+func newScope() *scope {
+	return &scope{symbols: map[string]*symbol{}}
+}
+
+type loop struct {
+	// döngü başladığında açık olan dene blokları
+	tries     int
+	breaks    []int
+	continues []int
+}
+
+// unit derlenmekte olan fonksiyondur
+type unit struct {
+	outer  *unit
+	fn     *object.CompiledFunction
+	scopes []*scope
+	// iç fonksiyonlarda geçen, hücrede tutulacak adlar
+	captured map[string]bool
+	locals   int
+	cells    int
+	loops    []*loop
+	tries    int
+}
+
+// define adı kapsamda yeni bir yuvaya ya da hücreye yerleştirir
+func (u *unit) define(s *scope, name string) *symbol {
+	sym := &symbol{}
+
+	if u.captured[name] {
+		sym.cell = true
+		sym.index = u.cells
+		u.cells++
+
+		if u.cells > u.fn.NumCells {
+			u.fn.NumCells = u.cells
+		}
+	} else {
+		sym.index = u.locals
+		u.locals++
+
+		if u.locals > u.fn.NumLocals {
+			u.fn.NumLocals = u.locals
+		}
+	}
+
+	s.symbols[name] = sym
+	return sym
+}
+
+func (u *unit) currentLoop() *loop {
+	if len(u.loops) == 0 {
+		return nil
+	}
+
+	return u.loops[len(u.loops)-1]
+}
+
+// resolve adı içten dışa kapsamlarda arar; dış fonksiyonlarda bulunan adlar
+// kapanışın serbest değişkenleri olur
+func resolve(u *unit, name string) (reference, bool) {
+	for i := len(u.scopes) - 1; i >= 0; i-- {
+		if sym, ok := u.scopes[i].symbols[name]; ok {
+			if sym.cell {
+				return reference{kind: cellRef, index: sym.index, symbol: sym}, true
+			}
+
+			return reference{kind: localRef, index: sym.index, symbol: sym}, true
+		}
+	}
+
+	if u.outer == nil {
+		return reference{kind: globalRef}, true
+	}
+
+	outer, ok := resolve(u.outer, name)
+	if !ok {
+		return outer, false
+	}
+
+	var free object.FreeVariable
+	switch outer.kind {
+	case globalRef:
+		return outer, true
+	case localRef:
+		// hücrede tutulmayan bir yerel yakalanamaz
+		return outer, false
+	case cellRef:
+		free = object.FreeVariable{Cell: true, Index: outer.index}
+	default:
+		free = object.FreeVariable{Index: outer.index}
+	}
+
+	for i, f := range u.fn.Free {
+		if f == free {
+			return reference{kind: freeRef, index: i, symbol: outer.symbol}, true
+		}
+	}
+
+	u.fn.Free = append(u.fn.Free, free)
+	return reference{kind: freeRef, index: len(u.fn.Free) - 1, symbol: outer.symbol}, true
+}
+
+// visible adın fonksiyonun dışında tanımlı olup olmadığına bakar
+func visible(u *unit, name string, globals map[string]bool) bool {
+	for ; u != nil; u = u.outer {
+		for _, s := range u.scopes {
+			if _, ok := s.symbols[name]; ok {
+				return true
+			}
+		}
+	}
+
+	return globals[name]
+}
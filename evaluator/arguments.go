@@ -0,0 +1,62 @@
+package evaluator
+
+import (
+	"github.com/ankalang/anka/ast"
+	"github.com/ankalang/anka/object"
+)
+
+func evalKeywordArguments(keywords []*ast.KeywordArgument, env *object.Environment) ([]object.KeywordArgument, object.Object) {
+	evaluated := make([]object.KeywordArgument, 0, len(keywords))
+
+	for _, k := range keywords {
+		value := Eval(k.Value, env)
+		if isError(value) {
+			return nil, value
+		}
+
+		evaluated = append(evaluated, object.KeywordArgument{Token: k.Token, Name: k.Name, Value: value})
+	}
+
+	return evaluated, nil
+}
+
+func functionName(fn *object.Function) string {
+	if fn.Name == "" {
+		return "isimsiz"
+	}
+
+	return fn.Name
+}
+
+// parameterIndex ada göre parametrenin sırasını döner; kalan ve kalıp
+// parametreleri isimle verilemez
+func parameterIndex(fn *object.Function, name string) int {
+	for i, param := range fn.Parameters {
+		if !param.Rest && param.Pattern == nil && param.Value == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// namedArguments isimli argümanları parametrelerle eşler, bilinmeyen ya da
+// sıralı olarak zaten verilmiş adlar hatadır
+func namedArguments(fn *object.Function, args []object.Object, keywords []object.KeywordArgument) (map[string]object.Object, *object.Error) {
+	named := map[string]object.Object{}
+
+	for _, k := range keywords {
+		idx := parameterIndex(fn, k.Name)
+		if idx == -1 {
+			return nil, newError(k.Token, "%s fonksiyonunun '%s' adında bir parametresi yok", functionName(fn), k.Name)
+		}
+
+		if idx < len(args) {
+			return nil, newError(k.Token, "%s fonksiyonunun '%s' parametresine hem sıralı hem isimli argüman verildi", functionName(fn), k.Name)
+		}
+
+		named[k.Name] = k.Value
+	}
+
+	return named, nil
+}
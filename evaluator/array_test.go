@@ -0,0 +1,25 @@
+package evaluator
+
+import "testing"
+
+// it(...) her türden değeri diziye ekleyebilmeli
+func TestPushAnyValue(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"a = []; a.it(1.5d); a", "[1.5]"},
+		{"a = []; a.it(Doğru); a", "[true]"},
+		{"a = []; a.it(küme([1])); a[0].tip()", "SET"},
+		{`a = []; a.it(bayt("ab")); a[0].tip()`, "BYTES"},
+		{"a = []; a.it(şimdi()); a[0].tip()", "TIME"},
+		{"a = []; a.it(f(x) { dön x }); a[0](7)", "7"},
+		{"tip N { x = 0 }\na = []; a.it(N(3)); a[0].x", "3"},
+		{"görevler = []; görevler.it(eşzamanlı(f() { dön 42 })); görevler[0].bekle()", "42"},
+		{"d = []; döngü x in [1, 2] { d.it(ondalık(x) / 4d) }; d", "[0.25, 0.5]"},
+	}
+
+	for _, tt := range tests {
+		expect(t, tt.code, tt.want)
+	}
+}
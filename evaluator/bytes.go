@@ -0,0 +1,352 @@
+package evaluator
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+var hashAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// bytesOf baytları olduğu gibi, metinleri UTF-8 olarak döner
+func bytesOf(o object.Object) []byte {
+	switch o := o.(type) {
+	case *object.Bytes:
+		return o.Value
+	case *object.String:
+		return []byte(o.Value)
+	}
+
+	return nil
+}
+
+func isLatin1(encoding string) bool {
+	return encoding == "latin-1" || encoding == "latin1" || encoding == "iso-8859-1"
+}
+
+func isUTF8(encoding string) bool {
+	return encoding == "utf-8" || encoding == "utf8"
+}
+
+// bayt("merhaba"), bayt("café", "latin-1") ya da bayt([104, 105])
+func bytesFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "bayt", args, [][][]string{
+		{{object.STRING_OBJ}, {object.STRING_OBJ}},
+		{{object.STRING_OBJ, object.ARRAY_OBJ, object.BYTES_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	switch arg := args[0].(type) {
+	case *object.String:
+		encoding := "utf-8"
+		if spec == 0 {
+			encoding = strings.ToLower(args[1].(*object.String).Value)
+		}
+
+		if isUTF8(encoding) {
+			return &object.Bytes{Token: tok, Value: []byte(arg.Value)}
+		}
+
+		if !isLatin1(encoding) {
+			return newError(tok, "bilinmeyen kodlama '%s', geçerli kodlamalar: utf-8, latin-1", encoding)
+		}
+
+		value := make([]byte, 0, len(arg.Value))
+		for _, r := range arg.Value {
+			if r > 0xFF {
+				return newError(tok, "'%c' karakteri latin-1 ile kodlanamaz", r)
+			}
+
+			value = append(value, byte(r))
+		}
+
+		return &object.Bytes{Token: tok, Value: value}
+	case *object.Array:
+		value := make([]byte, 0, len(arg.Elements))
+		for i, e := range arg.Elements {
+			n, ok := e.(*object.Number)
+			if !ok || !n.IsInt() || n.Value < 0 || n.Value > 255 {
+				return newError(tok, "bayt(...) dizisi 0 ile 255 arasında tamsayılardan oluşmalı, %d. eleman %s", i, e.Inspect())
+			}
+
+			value = append(value, byte(n.Value))
+		}
+
+		return &object.Bytes{Token: tok, Value: value}
+	case *object.Bytes:
+		return &object.Bytes{Token: tok, Value: append([]byte{}, arg.Value...)}
+	}
+
+	return NULL
+}
+
+// metin(b, kodlama?) baytları metne çevirir, geçersiz UTF-8 hata verir
+func bytesToStringFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "metin", args, [][][]string{
+		{{object.BYTES_OBJ}, {object.STRING_OBJ}},
+		{{object.BYTES_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	value := args[0].(*object.Bytes).Value
+	encoding := "utf-8"
+
+	if spec == 0 {
+		encoding = strings.ToLower(args[1].(*object.String).Value)
+	}
+
+	if isLatin1(encoding) {
+		runes := make([]rune, len(value))
+		for i, b := range value {
+			runes[i] = rune(b)
+		}
+
+		return &object.String{Token: tok, Value: string(runes)}
+	}
+
+	if !isUTF8(encoding) {
+		return newError(tok, "bilinmeyen kodlama '%s', geçerli kodlamalar: utf-8, latin-1", encoding)
+	}
+
+	if !utf8.Valid(value) {
+		return newError(tok, "baytlar geçerli bir UTF-8 metni değil")
+	}
+
+	return &object.String{Token: tok, Value: string(value)}
+}
+
+func base64Encoding(tok token.Token, name string, args []object.Object) (*base64.Encoding, object.Object) {
+	if len(args) < 2 {
+		return base64.StdEncoding, nil
+	}
+
+	switch args[1].(*object.String).Value {
+	case "url":
+		return base64.URLEncoding, nil
+	case "standart":
+		return base64.StdEncoding, nil
+	}
+
+	return nil, newError(tok, "%s(...) için bilinmeyen biçim '%s', geçerli biçimler: standart, url", name, args[1].Inspect())
+}
+
+func base64EncodeFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, _ := validateVarArgs(tok, "base64_kodla", args, [][][]string{
+		{{object.STRING_OBJ, object.BYTES_OBJ}, {object.STRING_OBJ}},
+		{{object.STRING_OBJ, object.BYTES_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	encoding, err := base64Encoding(tok, "base64_kodla", args)
+	if err != nil {
+		return err
+	}
+
+	return &object.String{Token: tok, Value: encoding.EncodeToString(bytesOf(args[0]))}
+}
+
+func base64DecodeFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, _ := validateVarArgs(tok, "base64_çöz", args, [][][]string{
+		{{object.STRING_OBJ}, {object.STRING_OBJ}},
+		{{object.STRING_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	encoding, err := base64Encoding(tok, "base64_çöz", args)
+	if err != nil {
+		return err
+	}
+
+	value, decodeErr := encoding.DecodeString(args[0].(*object.String).Value)
+	if decodeErr != nil {
+		return newError(tok, "base64 çözülemedi: %s", decodeErr.Error())
+	}
+
+	return &object.Bytes{Token: tok, Value: value}
+}
+
+func hexEncodeFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "hex_kodla", args, 1, [][]string{{object.STRING_OBJ, object.BYTES_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	return &object.String{Token: tok, Value: hex.EncodeToString(bytesOf(args[0]))}
+}
+
+func hexDecodeFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "hex_çöz", args, 1, [][]string{{object.STRING_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	value, decodeErr := hex.DecodeString(args[0].(*object.String).Value)
+	if decodeErr != nil {
+		return newError(tok, "hex çözülemedi: %s", decodeErr.Error())
+	}
+
+	return &object.Bytes{Token: tok, Value: value}
+}
+
+// sha256("veri") gibi özet fonksiyonları; sonuç bayt olarak döner, .hex_kodla() ile yazılabilir
+func digestFn(name string) object.BuiltinFunction {
+	return func(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+		err := validateArgs(tok, name, args, 1, [][]string{{object.STRING_OBJ, object.BYTES_OBJ}})
+		if err != nil {
+			return err
+		}
+
+		h := hashAlgorithms[name]()
+		h.Write(bytesOf(args[0]))
+
+		return &object.Bytes{Token: tok, Value: h.Sum(nil)}
+	}
+}
+
+// hmac("sha256", anahtar, veri)
+func hmacFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "hmac", args, 3, [][]string{
+		{object.STRING_OBJ},
+		{object.STRING_OBJ, object.BYTES_OBJ},
+		{object.STRING_OBJ, object.BYTES_OBJ},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	algorithm := strings.ToLower(args[0].(*object.String).Value)
+	newHash, ok := hashAlgorithms[algorithm]
+
+	if !ok {
+		return newError(tok, "bilinmeyen özet algoritması '%s', geçerli algoritmalar: md5, sha1, sha256, sha512", algorithm)
+	}
+
+	mac := hmac.New(newHash, bytesOf(args[1]))
+	mac.Write(bytesOf(args[2]))
+
+	return &object.Bytes{Token: tok, Value: mac.Sum(nil)}
+}
+
+// güvenli_eşit imzaları karşılaştırırken süre farkından bilgi sızdırmaz
+func constantTimeEqualFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "güvenli_eşit", args, 2, [][]string{
+		{object.STRING_OBJ, object.BYTES_OBJ},
+		{object.STRING_OBJ, object.BYTES_OBJ},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nativeBoolToBooleanObject(subtle.ConstantTimeCompare(bytesOf(args[0]), bytesOf(args[1])) == 1)
+}
+
+func evalBytesInfixExpression(tok token.Token, operator string, left, right object.Object) object.Object {
+	l := left.(*object.Bytes).Value
+
+	if s, ok := right.(*object.String); ok && (operator == ">" || operator == ">>") {
+		write := writeFile
+		if operator == ">>" {
+			write = appendFile
+		}
+
+		if err := write(s.Value, string(l)); err != nil {
+			return newError(tok, "%s'e yazarken başarısız olundu: %s", s.Value, err.Error())
+		}
+
+		return TRUE
+	}
+
+	r, ok := right.(*object.Bytes)
+	if !ok {
+		return newError(tok, "Tip uyuşmazlığı %s %s %s", left.Type(), operator, right.Type())
+	}
+
+	switch operator {
+	case "+":
+		value := make([]byte, 0, len(l)+len(r.Value))
+		return &object.Bytes{Token: tok, Value: append(append(value, l...), r.Value...)}
+	case "==":
+		return nativeBoolToBooleanObject(bytes.Equal(l, r.Value))
+	case "!=":
+		return nativeBoolToBooleanObject(!bytes.Equal(l, r.Value))
+	case "in":
+		return evalInExpression(tok, left, right)
+	case "!in":
+		return evalNotInExpression(tok, left, right)
+	}
+
+	return newError(tok, "Bilinmeyen operatör: %s %s %s", left.Type(), operator, right.Type())
+}
+
+// dizi ve metinlerle aynı kurallar: negatif dizinler sondan sayılır, aralıklar kesilir
+func evalBytesIndexExpression(tok token.Token, left, index object.Object, end object.Object, isRange bool) object.Object {
+	value := left.(*object.Bytes).Value
+	idx := index.(*object.Number).Int()
+	max := len(value) - 1
+
+	if isRange {
+		max++
+
+		if idx < 0 {
+			idx = 0
+		}
+
+		if endIdx, ok := end.(*object.Number); ok {
+			if endIdx.Int() < 0 {
+				max = int(math.Max(float64(max+endIdx.Int()), 0))
+			} else if endIdx.Int() < max {
+				max = endIdx.Int()
+			}
+		} else if end != NULL {
+			return newError(tok, `dizinler sayı olmalıdır fakat "%s" bulundu. (tip %s)`, end.Inspect(), end.Type())
+		}
+
+		if idx > max {
+			return &object.Bytes{Token: tok, Value: []byte{}}
+		}
+
+		return &object.Bytes{Token: tok, Value: value[idx:max]}
+	}
+
+	if idx < 0 {
+		idx += len(value)
+	}
+
+	if idx < 0 || idx > max {
+		return NULL
+	}
+
+	return object.NewInteger(tok, int64(value[idx]))
+}
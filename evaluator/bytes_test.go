@@ -0,0 +1,61 @@
+package evaluator
+
+import "testing"
+
+func TestBytes(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{`bayt("hi")`, `bayt("hi")`},
+		{`bayt([104, 105])`, `bayt("hi")`},
+		{`metin(bayt([104, 105]))`, "hi"},
+		{`bayt("é").uzunluk()`, "2"},
+		{`bayt("café", "latin-1").uzunluk()`, "4"},
+		{`metin(bayt("café", "latin-1"), "latin-1")`, "café"},
+		{`base64_kodla("merhaba")`, "bWVyaGFiYQ=="},
+		{`metin(base64_çöz("bWVyaGFiYQ=="))`, "merhaba"},
+		{`base64_kodla(bayt([251, 255]), "url")`, "-_8="},
+		{`hex_kodla("hi")`, "6869"},
+		{`hex_çöz("6869")`, `bayt("hi")`},
+		{`hex_kodla(md5("abc"))`, "900150983cd24fb0d6963f7d28e17f72"},
+		{`hex_kodla(sha256(""))`, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{`hex_kodla(hmac("sha256", "k", "m"))`, "b60090e3052297aeb5a080889ce2fc4bca957e756faeb4df7d31800ca1e771ec"},
+		{`güvenli_eşit("a", bayt("a"))`, "true"},
+		{`bayt("ab") + bayt("c")`, `bayt("abc")`},
+		{`bayt("ab") == bayt("ab")`, "true"},
+		{`bayt("abc")[1]`, "98"},
+		{`bayt("abc")[-1]`, "99"},
+		{`bayt("abc")[5]`, "null"},
+		{`bayt("abc")[1:]`, `bayt("bc")`},
+		{`98 in bayt("abc")`, "true"},
+		{`bayt("b") in bayt("abc")`, "true"},
+	}
+
+	for _, tt := range tests {
+		expect(t, tt.code, tt.want)
+	}
+
+	errors := []struct {
+		code    string
+		message string
+	}{
+		{`bayt([256])`, "bayt(...) dizisi 0 ile 255 arasında tamsayılardan oluşmalı, 0. eleman 256"},
+		{`bayt([1, -1])`, "1. eleman -1"},
+		{`bayt([1.5])`, "0. eleman 1.5"},
+		{`bayt(["a"])`, "0. eleman a"},
+		{`bayt("ğ", "latin-1")`, "'ğ' karakteri latin-1 ile kodlanamaz"},
+		{`bayt("a", "utf-16")`, "bilinmeyen kodlama 'utf-16'"},
+		{`metin(bayt([255]))`, "baytlar geçerli bir UTF-8 metni değil"},
+		{`base64_çöz("!!")`, "base64 çözülemedi"},
+		{`base64_kodla("a", "x")`, "base64_kodla(...) için bilinmeyen biçim 'x'"},
+		{`hex_çöz("zz")`, "hex çözülemedi"},
+		{`hmac("crc", "k", "m")`, "bilinmeyen özet algoritması 'crc'"},
+		{`bayt("a") + "b"`, "Tip uyuşmazlığı BYTES + STRING"},
+		{`bayt("a") * bayt("b")`, "Bilinmeyen operatör: BYTES * BYTES"},
+	}
+
+	for _, tt := range errors {
+		expectError(t, tt.code, tt.message)
+	}
+}
@@ -0,0 +1,92 @@
+package evaluator
+
+import (
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+// iç içe yapılabilecek en fazla fonksiyon çağrısı; ANK_CALL_DEPTH ile
+// değiştirilebilir, 0 sınırı kaldırır
+const ANK_CALL_DEPTH = "10000"
+
+// tailCall kuyruk konumundaki bir dön ifadesinin henüz yapılmamış çağrısıdır;
+// çağıran fonksiyon kendi ortamını bırakıp çağrıyı yerinde yapar
+type tailCall struct {
+	tok      token.Token
+	fn       object.Object
+	env      *object.Environment
+	args     []object.Object
+	keywords []object.KeywordArgument
+}
+
+func (tc *tailCall) Type() object.ObjectType { return "TAIL_CALL" }
+func (tc *tailCall) Inspect() string         { return "kuyruk çağrısı" }
+func (tc *tailCall) Json() string            { return tc.Inspect() }
+
+// applyUserFunction fonksiyonu çalıştırır. Gövde kuyruk konumunda bir çağrı
+// dönerse çağrı Go yığını büyütülmeden aynı döngüde yapılır.
+func applyUserFunction(tok token.Token, fn *object.Function, env *object.Environment, args []object.Object, keywords []object.KeywordArgument) object.Object {
+	caller := env.ActiveCall()
+	call := &object.Call{Function: functionName(fn), Token: tok, Caller: caller, Depth: 1}
+	if caller != nil {
+		call.Depth = caller.Depth + 1
+	}
+
+	limit := MaxCallDepth(env)
+	if limit > 0 && call.Depth > limit {
+		return CallDepthError(tok, limit)
+	}
+
+	for {
+		extendedEnv, err := extendFunctionEnv(fn, call, args, keywords)
+		if err != nil {
+			return err
+		}
+
+		evaluated := unwrapReturnValue(Eval(fn.Body, extendedEnv))
+		next, ok := evaluated.(*tailCall)
+		if !ok {
+			return unwind(evaluated, call)
+		}
+
+		nextFn, ok := next.fn.(*object.Function)
+		if !ok {
+			return unwind(callFunction(next.tok, next.fn, next.env, next.args, next.keywords), call)
+		}
+
+		// kuyruk çağrısı çağıranın yerini alır, derinlik artmaz
+		fn, args, keywords = nextFn, next.args, next.keywords
+		call = &object.Call{Function: functionName(fn), Token: next.tok, Caller: caller, Depth: call.Depth}
+	}
+}
+
+// MaxCallDepth ortamı çalıştıran yorumlayıcının çağrı derinliği sınırını döner
+func MaxCallDepth(env *object.Environment) int {
+	return interpreterOf(env).maxCallDepth(env)
+}
+
+// CallDepthError çağrı derinliği sınırı aşıldığında dönen hatadır; çağrı
+// yığını hata çerçevelerden çıkarken dolar
+func CallDepthError(tok token.Token, limit int) *object.Error {
+	return newError(tok, "en fazla %d iç içe fonksiyon çağrısı yapılabilir (ANK_CALL_DEPTH)", limit)
+}
+
+// NewFrame tok konumunda yapılan çağrının hata yığınındaki çerçevesidir
+func NewFrame(function string, tok token.Token) object.Frame {
+	frame := object.Frame{Function: function}
+	if tok.Source != nil {
+		frame.Line, frame.Column, _ = tok.Source.ErrorLine(tok.Position)
+		frame.File = tok.Source.File()
+	}
+
+	return frame
+}
+
+// unwind fonksiyondan çıkan hataya çağrının çerçevesini ekler
+func unwind(result object.Object, call *object.Call) object.Object {
+	if err, ok := result.(*object.Error); ok {
+		err.Stack = append(err.Stack, NewFrame(call.Function, call.Token))
+	}
+
+	return result
+}
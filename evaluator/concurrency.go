@@ -0,0 +1,280 @@
+package evaluator
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+// çöken bir görev tüm programı düşürmek yerine hata döner
+func runTask(tok token.Token, fn object.Object, env *object.Environment, args []object.Object) (result object.Object) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = newError(tok, "görev çöktü: %s", fmt.Sprint(r))
+		}
+	}()
+
+	return applyFunction(tok, fn, env, args)
+}
+
+func spawnFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	if len(args) == 0 {
+		return newError(tok, "eşzamanlı(...) için yanlış sayıda argüman: bulunan=0, en az=1")
+	}
+
+	if args[0].Type() != object.FUNCTION_OBJ && args[0].Type() != object.BUILTIN_OBJ {
+		return newError(tok, "eşzamanlı(...) argümanı 0 FUNCTION olmalı, bulunan %s", args[0].Type())
+	}
+
+	task := &object.Task{Token: tok, Done: make(chan struct{})}
+
+	go func() {
+		task.Result = runTask(tok, args[0], env, args[1:])
+		close(task.Done)
+	}()
+
+	return task
+}
+
+// görev.bekle() görevin sonucunu döner, grup.bekle() gruptaki tüm işlerin bitmesini bekler.
+// bekle aynı zamanda erteleme anahtar kelimesi olduğundan bekle(görev) biçimi
+// ayrıştırılamaz; yalnızca metot biçimi kullanılır.
+func awaitFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "bekle", args, [][][]string{
+		{{object.TASK_OBJ}, {object.NUMBER_OBJ}},
+		{{object.TASK_OBJ}},
+		{{object.WAITGROUP_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if group, ok := args[0].(*object.WaitGroup); ok {
+		group.Wait()
+		return NULL
+	}
+
+	task := args[0].(*object.Task)
+
+	if spec == 0 {
+		timeout := time.Duration(args[1].(*object.Number).Value * float64(time.Millisecond))
+
+		select {
+		case <-task.Done:
+		case <-time.After(timeout):
+			return newError(tok, "görev %s içinde bitmedi", timeout)
+		}
+	}
+
+	<-task.Done
+	return task.Result
+}
+
+func channelFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "kanal", args, [][][]string{
+		{{object.NUMBER_OBJ}},
+		{},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	capacity := 0
+	if spec == 0 {
+		capacity = args[0].(*object.Number).Int()
+	}
+
+	if capacity < 0 {
+		return newError(tok, "kanal kapasitesi negatif olamaz: %d", capacity)
+	}
+
+	return &object.Channel{Token: tok, Value: make(chan object.Object, capacity)}
+}
+
+func sendFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "gönder", args, 2, [][]string{{object.CHANNEL_OBJ}, {object.ANY_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	if !args[0].(*object.Channel).Send(args[1]) {
+		return newError(tok, "kapalı kanala gönderilemez")
+	}
+
+	return NULL
+}
+
+// kanal kapatılıp boşaldığında null döner
+func receiveFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "al", args, 1, [][]string{{object.CHANNEL_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	v, ok := <-args[0].(*object.Channel).Value
+	if !ok {
+		return NULL
+	}
+
+	return v
+}
+
+func closeFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "kapat", args, 1, [][]string{{object.CHANNEL_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	if !args[0].(*object.Channel).Close() {
+		return newError(tok, "kanal zaten kapalı")
+	}
+
+	return NULL
+}
+
+// seç([k1, k2], 1000) veri gelen ilk kanalı {"sıra", "değer", "açık"} olarak döner,
+// zaman aşımında null döner
+func selectFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "seç", args, [][][]string{
+		{{object.ARRAY_OBJ}, {object.NUMBER_OBJ}},
+		{{object.ARRAY_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	channels := args[0].(*object.Array).Elements
+	cases := []reflect.SelectCase{}
+
+	for i, c := range channels {
+		channel, ok := c.(*object.Channel)
+		if !ok {
+			return newError(tok, "seç(...) yalnızca kanallarla çalışır, %d. eleman %s", i, c.Type())
+		}
+
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(channel.Value)})
+	}
+
+	if spec == 0 {
+		timeout := time.Duration(args[1].(*object.Number).Value * float64(time.Millisecond))
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(time.After(timeout))})
+	}
+
+	chosen, value, ok := reflect.Select(cases)
+
+	if chosen == len(channels) {
+		return NULL
+	}
+
+	var received object.Object = NULL
+	if ok {
+		received = value.Interface().(object.Object)
+	}
+
+	return hashFromEntries(tok, []hashEntry{
+		{"sıra", object.NewInteger(tok, int64(chosen))},
+		{"değer", received},
+		{"açık", nativeBoolToBooleanObject(ok)},
+	})
+}
+
+func waitGroupFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	return &object.WaitGroup{Token: tok}
+}
+
+func waitGroupAddFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "artır", args, [][][]string{
+		{{object.WAITGROUP_OBJ}, {object.NUMBER_OBJ}},
+		{{object.WAITGROUP_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	n := 1
+	if spec == 0 {
+		n = args[1].(*object.Number).Int()
+	}
+
+	if !args[0].(*object.WaitGroup).Add(n) {
+		return newError(tok, "artır(...) bekleme grubunun sayacını eksiye düşüremez")
+	}
+
+	return NULL
+}
+
+func waitGroupDoneFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "tamamla", args, 1, [][]string{{object.WAITGROUP_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	if !args[0].(*object.WaitGroup).Add(-1) {
+		return newError(tok, "tamamla(...) bekleme grubunda bitmemiş iş yok")
+	}
+
+	return NULL
+}
+
+// sonuçlar dizideki sırayla döner, herhangi bir eleman hata verirse ilk hata döner
+func parallelMapFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "paralel_haritala", args, [][][]string{
+		{{object.ARRAY_OBJ}, {object.FUNCTION_OBJ, object.BUILTIN_OBJ}, {object.NUMBER_OBJ}},
+		{{object.ARRAY_OBJ}, {object.FUNCTION_OBJ, object.BUILTIN_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	elements := args[0].(*object.Array).Elements
+	workers := runtime.NumCPU()
+
+	if spec == 0 {
+		workers = args[2].(*object.Number).Int()
+	}
+
+	if workers < 1 {
+		return newError(tok, "işçi sayısı en az 1 olmalı, bulunan %d", workers)
+	}
+
+	results := make([]object.Object, len(elements))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				results[i] = runTask(tok, args[1], env, []object.Object{elements[i]})
+			}
+		}()
+	}
+
+	for i := range elements {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	for _, result := range results {
+		if isError(result) {
+			return result
+		}
+	}
+
+	return &object.Array{Token: tok, Elements: results}
+}
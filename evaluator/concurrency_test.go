@@ -0,0 +1,154 @@
+package evaluator
+
+import "testing"
+
+// aynı diziyi okuyan eşzamanlı döngüler birbirinin imlecini kaydırmamalı;
+// go test -race ile veri yarışı da olmamalı
+func TestConcurrentLoopsOverSharedArray(t *testing.T) {
+	expect(t, `
+d = [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20]
+f topla(_) {
+    t = 0
+    döngü x in d {
+        t = t + x
+    }
+    dön t
+}
+paralel_haritala([1, 2, 3, 4, 5, 6, 7, 8], topla, 8)
+`, "[210, 210, 210, 210, 210, 210, 210, 210]")
+
+	expect(t, `
+h = {"a": 1, "b": 2, "c": 3}
+f topla() {
+    t = 0
+    döngü _, v in h {
+        t = t + v
+    }
+    dön t
+}
+görevler = (1..8).haritala(f(_) { eşzamanlı(topla) })
+görevler.haritala(f(g) { g.bekle() })
+`, "[6, 6, 6, 6, 6, 6, 6, 6]")
+}
+
+func TestNestedLoopsOverSameValue(t *testing.T) {
+	expect(t, `
+d = [1, 2, 3]
+n = 0
+döngü a in d {
+    döngü b in d {
+        n = n + a * b
+    }
+}
+n
+`, "36")
+
+	expect(t, `
+h = {"x": 1, "y": 2}
+çiftler = []
+döngü k, _ in h {
+    döngü l, _ in h {
+        çiftler.it(k + l)
+    }
+}
+çiftler
+`, `["xx", "xy", "yx", "yy"]`)
+
+	// yarıda bırakılan döngü sonraki döngüyü etkilemez
+	expect(t, `
+d = [1, 2, 3]
+döngü x in d {
+    dur
+}
+ilk = []
+döngü x in d {
+    ilk.it(x)
+}
+ilk
+`, "[1, 2, 3]")
+}
+
+func TestLoopSkipsRemovedHashKeys(t *testing.T) {
+	expect(t, `
+h = {"a": 1, "b": 2, "c": 3}
+görülen = []
+döngü k, v in h {
+    görülen.it(k)
+    h.çıkar("b")
+}
+görülen
+`, `["a", "c"]`)
+}
+
+func TestWaitGroup(t *testing.T) {
+	expect(t, `
+g = bekleme_grubu()
+k = kanal(3)
+g.artır(3)
+döngü i in 1..3 {
+    eşzamanlı(f(i) { k.gönder(i); g.tamamla() }, i)
+}
+g.bekle()
+k.kapat()
+toplam = 0
+döngü _, v in k {
+    toplam = toplam + v
+}
+toplam
+`, "6")
+
+	expectError(t, `g = bekleme_grubu(); g.tamamla()`, "bitmemiş iş yok")
+	expectError(t, `g = bekleme_grubu(); g.artır(2); g.artır(-3)`, "eksiye düşüremez")
+
+	// hatalı çağrıdan sonra grup kullanılmaya devam edilebilir
+	expect(t, `
+g = bekleme_grubu()
+dene { g.tamamla() } yakala e { }
+g.artır()
+g.tamamla()
+g.bekle()
+"bitti"
+`, "bitti")
+}
+
+// görevler arasında paylaşılan haritaya ve diziye aynı anda yazılabilmeli;
+// go test -race ile çalıştırılmalı
+func TestConcurrentWritesToSharedCollections(t *testing.T) {
+	expect(t, `
+h = {}
+f yaz(i) {
+    döngü j = 0; j < 50; j = j + 1 {
+        h["$i-$j"] = j
+        h[i] = h.anahtarlar().uzunluk()
+    }
+    dön i
+}
+paralel_haritala((1..8), yaz, 8)
+h.anahtarlar().uzunluk()
+`, "408")
+
+	expect(t, `
+d = []
+f ekle(i) {
+    döngü j = 0; j < 50; j = j + 1 {
+        d.it(j)
+    }
+}
+görevler = (1..8).haritala(f(i) { eşzamanlı(ekle, i) })
+görevler.haritala(f(g) { g.bekle() })
+d.uzunluk()
+`, "400")
+
+	expect(t, `
+h = {}
+d = []
+f sil(i) {
+    h[i] = i
+    d.it(i)
+    h.çıkar(i)
+    d.çıkar()
+}
+paralel_haritala((1..8), sil, 8)
+[h.anahtarlar().uzunluk(), d.uzunluk()]
+`, "[0, 0]")
+}
@@ -0,0 +1,282 @@
+package evaluator
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+// bölme sonucu tam çıkmadığında virgülden sonra tutulacak basamak sayısı
+const decimalDivisionScale = 18
+
+// yuvarlama kipleri, varsayılan yarım_yukarı
+var decimalRoundingModes = []string{"yarım_yukarı", "yarım_aşağı", "yarım_çift", "yukarı", "aşağı", "tavan", "taban"}
+
+func isDecimalRoundingMode(mode string) bool {
+	for _, m := range decimalRoundingModes {
+		if m == mode {
+			return true
+		}
+	}
+
+	return false
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// parseDecimal "12.30", "-0.5" ya da "1.2e3" gibi yazıları kesin olarak okur
+func parseDecimal(tok token.Token, s string) (*object.Decimal, bool) {
+	mantissa, exponent := s, 0
+
+	if i := strings.IndexAny(s, "eE"); i != -1 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return nil, false
+		}
+
+		mantissa, exponent = s[:i], e
+	}
+
+	whole, fraction := mantissa, ""
+	if i := strings.Index(mantissa, "."); i != -1 {
+		whole, fraction = mantissa[:i], mantissa[i+1:]
+	}
+
+	sign := ""
+	if strings.HasPrefix(whole, "-") || strings.HasPrefix(whole, "+") {
+		sign, whole = whole[:1], whole[1:]
+	}
+
+	digits := whole + fraction
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return nil, false
+	}
+
+	unscaled, _ := new(big.Int).SetString(sign+digits, 10)
+	scale := len(fraction) - exponent
+
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+
+	return &object.Decimal{Token: tok, Unscaled: unscaled, Scale: scale}, true
+}
+
+// toDecimal sayıları ve ondalıkları ortak tipe çevirir; float64 değerler en kısa
+// yazılışlarıyla çevrilir, böylece 0.1 gerçekten 0.1 olur
+func toDecimal(tok token.Token, o object.Object) (*object.Decimal, bool) {
+	switch o := o.(type) {
+	case *object.Decimal:
+		return o, true
+	case *object.Number:
+		if o.Integer {
+			return &object.Decimal{Token: tok, Unscaled: o.BigInt(), Scale: 0}, true
+		}
+
+		if math.IsNaN(o.Value) || math.IsInf(o.Value, 0) {
+			return nil, false
+		}
+
+		return parseDecimal(tok, strconv.FormatFloat(o.Value, 'f', -1, 64))
+	case *object.String:
+		return parseDecimal(tok, strings.TrimSpace(o.Value))
+	}
+
+	return nil, false
+}
+
+// divideRounded n / m bölümünü verilen kipe göre tamsayıya yuvarlar
+func divideRounded(n, m *big.Int, mode string) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(n, m, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	sign := n.Sign() * m.Sign()
+	half := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).CmpAbs(m)
+
+	var away bool
+	switch mode {
+	case "yukarı":
+		away = true
+	case "aşağı":
+		away = false
+	case "tavan":
+		away = sign > 0
+	case "taban":
+		away = sign < 0
+	case "yarım_aşağı":
+		away = half > 0
+	case "yarım_çift":
+		away = half > 0 || (half == 0 && quotient.Bit(0) == 1)
+	default:
+		away = half >= 0
+	}
+
+	if away {
+		quotient.Add(quotient, big.NewInt(int64(sign)))
+	}
+
+	return quotient
+}
+
+func rescaleDecimal(tok token.Token, d *object.Decimal, scale int, mode string) *object.Decimal {
+	if scale >= d.Scale {
+		return &object.Decimal{Token: tok, Unscaled: new(big.Int).Mul(d.Unscaled, pow10(scale-d.Scale)), Scale: scale}
+	}
+
+	return &object.Decimal{Token: tok, Unscaled: divideRounded(d.Unscaled, pow10(d.Scale-scale), mode), Scale: scale}
+}
+
+// sondaki gereksiz sıfırları en az minScale basamak kalacak şekilde atar
+func trimDecimal(d *object.Decimal, minScale int) *object.Decimal {
+	ten := big.NewInt(10)
+	remainder := new(big.Int)
+
+	for d.Scale > minScale {
+		quotient, r := new(big.Int).QuoRem(d.Unscaled, ten, remainder)
+		if r.Sign() != 0 {
+			break
+		}
+
+		d = &object.Decimal{Token: d.Token, Unscaled: quotient, Scale: d.Scale - 1}
+	}
+
+	return d
+}
+
+func isDecimalOperand(left, right object.Object) bool {
+	isNumeric := func(o object.Object) bool {
+		return o.Type() == object.NUMBER_OBJ || o.Type() == object.DECIMAL_OBJ
+	}
+
+	return isNumeric(left) && isNumeric(right) && (left.Type() == object.DECIMAL_OBJ || right.Type() == object.DECIMAL_OBJ)
+}
+
+func evalDecimalInfixExpression(tok token.Token, operator string, left, right object.Object) object.Object {
+	l, ok := toDecimal(tok, left)
+	if !ok {
+		return newError(tok, "%s ondalık sayıya çevrilemez", left.Inspect())
+	}
+
+	r, ok := toDecimal(tok, right)
+	if !ok {
+		return newError(tok, "%s ondalık sayıya çevrilemez", right.Inspect())
+	}
+
+	scale := l.Scale
+	if r.Scale > scale {
+		scale = r.Scale
+	}
+
+	a := rescaleDecimal(tok, l, scale, "").Unscaled
+	b := rescaleDecimal(tok, r, scale, "").Unscaled
+
+	switch operator {
+	case "+":
+		return &object.Decimal{Token: tok, Unscaled: new(big.Int).Add(a, b), Scale: scale}
+	case "-":
+		return &object.Decimal{Token: tok, Unscaled: new(big.Int).Sub(a, b), Scale: scale}
+	case "*":
+		return &object.Decimal{Token: tok, Unscaled: new(big.Int).Mul(l.Unscaled, r.Unscaled), Scale: l.Scale + r.Scale}
+	case "/":
+		if b.Sign() == 0 {
+			return newError(tok, "Sıfıra bölme: %s / %s", left.Inspect(), right.Inspect())
+		}
+
+		resultScale := decimalDivisionScale
+		if scale > resultScale {
+			resultScale = scale
+		}
+
+		quotient := divideRounded(new(big.Int).Mul(a, pow10(resultScale)), b, "yarım_çift")
+		return trimDecimal(&object.Decimal{Token: tok, Unscaled: quotient, Scale: resultScale}, scale)
+	case "%":
+		if b.Sign() == 0 {
+			return newError(tok, "Sıfıra bölme: %s %% %s", left.Inspect(), right.Inspect())
+		}
+
+		return &object.Decimal{Token: tok, Unscaled: new(big.Int).Rem(a, b), Scale: scale}
+	case "**":
+		exponent := trimDecimal(r, 0)
+		if exponent.Scale != 0 || !exponent.Unscaled.IsInt64() {
+			return newError(tok, "ondalık sayılarda üs tamsayı olmalı, bulunan %s", right.Inspect())
+		}
+
+		n := exponent.Unscaled.Int64()
+		if n < 0 {
+			n = -n
+		}
+
+		// üs önce tek başına sınanır, çarpım int64'te taşmasın; -n de
+		// MinInt64 için eksi kalır
+		if n < 0 || n > maxIntegerBits || int64(l.Unscaled.BitLen()+l.Scale*4)*n > maxIntegerBits {
+			return newError(tok, "%s ** %s sonucu çok büyük", left.Inspect(), right.Inspect())
+		}
+
+		power := &object.Decimal{Token: tok, Unscaled: new(big.Int).Exp(l.Unscaled, big.NewInt(n), nil), Scale: l.Scale * int(n)}
+		if exponent.Unscaled.Sign() < 0 {
+			return evalDecimalInfixExpression(tok, "/", &object.Decimal{Token: tok, Unscaled: big.NewInt(1)}, power)
+		}
+
+		return power
+	case "<", ">", "<=", ">=", "==", "!=", "<=>":
+		return compareIntegers(tok, operator, a.Cmp(b))
+	}
+
+	return newError(tok, "Bilinmeyen operatör: %s %s %s", left.Type(), operator, right.Type())
+}
+
+// ondalık(x, ölçek?, kip?) x'i ondalık sayıya çevirir, ölçek verilirse o basamağa yuvarlar
+func decimalFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "ondalık", args, [][][]string{
+		{{object.STRING_OBJ, object.NUMBER_OBJ, object.DECIMAL_OBJ}, {object.NUMBER_OBJ}, {object.STRING_OBJ}},
+		{{object.STRING_OBJ, object.NUMBER_OBJ, object.DECIMAL_OBJ}, {object.NUMBER_OBJ}},
+		{{object.STRING_OBJ, object.NUMBER_OBJ, object.DECIMAL_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	d, ok := toDecimal(tok, args[0])
+	if !ok {
+		return newError(tok, "ondalık(...) '%s' değerini ondalık sayıya çeviremez", args[0].Inspect())
+	}
+
+	if spec == 2 {
+		return d
+	}
+
+	return roundDecimal(tok, d, args[1:])
+}
+
+// roundDecimal ondalık sayıyı [ölçek, kip] argümanlarına göre yuvarlar
+func roundDecimal(tok token.Token, d *object.Decimal, args []object.Object) object.Object {
+	scale, mode := 0, "yarım_yukarı"
+
+	if len(args) > 0 {
+		scale = args[0].(*object.Number).Int()
+	}
+
+	if scale < 0 {
+		return newError(tok, "ölçek negatif olamaz: %d", scale)
+	}
+
+	if len(args) > 1 {
+		mode = args[1].(*object.String).Value
+	}
+
+	if !isDecimalRoundingMode(mode) {
+		return newError(tok, "bilinmeyen yuvarlama kipi '%s', geçerli kipler: %s", mode, strings.Join(decimalRoundingModes, ", "))
+	}
+
+	return rescaleDecimal(tok, d, scale, mode)
+}
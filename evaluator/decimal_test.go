@@ -0,0 +1,64 @@
+package evaluator
+
+import "testing"
+
+func TestDecimalArithmetic(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"0.1d + 0.2d", "0.3"},
+		{"1.5d + 2", "3.5"},
+		{"10d / 3d", "3.333333333333333333"},
+		{"1.5d ** 2", "2.25"},
+		{"2d ** -1", "0.5"},
+		{`ondalık("2.50")`, "2.50"},
+		{"ondalık(1, 2)", "1.00"},
+		{"1.0d == 1", "true"},
+	}
+
+	for _, tt := range tests {
+		expect(t, tt.code, tt.want)
+	}
+}
+
+func TestDecimalErrors(t *testing.T) {
+	tests := []struct {
+		code    string
+		message string
+	}{
+		// üssün bit uzunluğuyla çarpımı int64'te taşardı
+		{"1.1d ** 4611686018427387904", "sonucu çok büyük"},
+		{"1.1d ** -9223372036854775808", "sonucu çok büyük"},
+		{"1.1d ** 16777217", "sonucu çok büyük"},
+		{"1d / 0d", "Sıfıra bölme"},
+		{"1.5d ** 0.5", "üs tamsayı olmalı"},
+		{`ondalık("abc")`, "ondalık sayıya çeviremez"},
+	}
+
+	for _, tt := range tests {
+		expectError(t, tt.code, tt.message)
+	}
+}
+
+// == ile eşit olan ondalıklar ve sayılar haritada aynı anahtardır
+func TestDecimalHashKeys(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{`h = {1: "a"}; h[1.0d]`, "a"},
+		{`h = {1.00d: "a"}; h[1]`, "a"},
+		{`h = {0.5: "a"}; h[0.50d]`, "a"},
+		{`h = {2 ** 64: "a"}; h[ondalık(2 ** 64)]`, "a"},
+		{`{1.50d: 1, 1.5d: 2}.anahtarlar().uzunluk()`, "1"},
+		{`{1: 1, 1.0d: 2}.anahtarlar().uzunluk()`, "1"},
+		// 0.1 sayısı ondalığa 0.1 olarak çevrilir, ondalık olan 1/3'ün eşi yoktur
+		{`h = {0.1: "a"}; h[0.1d]`, "a"},
+		{`{1d / 3d: 1, 0.3333333333333333: 2}.anahtarlar().uzunluk()`, "2"},
+	}
+
+	for _, tt := range tests {
+		expect(t, tt.code, tt.want)
+	}
+}
@@ -0,0 +1,144 @@
+package evaluator
+
+import (
+	"github.com/ankalang/anka/ast"
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+// destructure değeri atama ya da parametre kalıbına göre açar ve bulunan her
+// adı bind ile bağlar. eşle'den farklı olarak eksik elemanlar ve anahtarlar
+// hata değildir, varsayılanı yoksa null olur; dizi yerine harita gibi yapı
+// uyuşmazlıkları ise hatadır.
+func destructure(tok token.Token, pattern ast.Pattern, value object.Object, env *object.Environment, bind func(name string, val object.Object) object.Object) object.Object {
+	if value == nil {
+		value = NULL
+	}
+
+	switch p := pattern.(type) {
+	case *ast.WildcardPattern:
+		return nil
+	case *ast.BindingPattern:
+		return bind(p.Name, value)
+	case *ast.DefaultPattern:
+		if value.Type() == object.NULL_OBJ {
+			value = Eval(p.Default, env)
+			if isError(value) {
+				return value
+			}
+		}
+
+		return destructure(tok, p.Pattern, value, env, bind)
+	case *ast.TypePattern:
+		// atamalarda yalın büyük harfli adlar da değişkendir, Nokta {x, y} ya da
+		// Sayı n ise değerin tipini doğrular
+		if p.Binding == "" && p.Inner == nil {
+			return bind(p.TypeName, value)
+		}
+
+		matched, err := isOfType(p, value, env)
+		if err != nil {
+			return err
+		}
+
+		if !matched {
+			return newError(p.Token, "%s kalıbı için %s tipinde değer beklendi, %s bulundu", p.String(), p.TypeName, value.Type())
+		}
+
+		if p.Binding != "" {
+			if err := bind(p.Binding, value); err != nil {
+				return err
+			}
+		}
+
+		if p.Inner != nil {
+			return destructure(tok, p.Inner, value, env, bind)
+		}
+
+		return nil
+	case *ast.ArrayPattern:
+		return destructureArray(tok, p, value, env, bind)
+	case *ast.HashPattern:
+		return destructureHash(tok, p, value, env, bind)
+	}
+
+	return newError(tok, "%s kalıbı atamalarda kullanılamaz", pattern.String())
+}
+
+func destructureArray(tok token.Token, p *ast.ArrayPattern, value object.Object, env *object.Environment, bind func(string, object.Object) object.Object) object.Object {
+	array, ok := value.(*object.Array)
+	if !ok {
+		return newError(p.Token, "%s kalıbı için dizi beklendi, %s bulundu", p.String(), value.Type())
+	}
+
+	for i, element := range p.Elements {
+		var item object.Object = NULL
+		if i < len(array.Elements) {
+			item = array.Elements[i]
+		}
+
+		if err := destructure(tok, element, item, env, bind); err != nil {
+			return err
+		}
+	}
+
+	if p.Rest == "" {
+		return nil
+	}
+
+	rest := []object.Object{}
+	if len(array.Elements) > len(p.Elements) {
+		rest = append(rest, array.Elements[len(p.Elements):]...)
+	}
+
+	return bind(p.Rest, &object.Array{Token: p.Token, Elements: rest})
+}
+
+func destructureHash(tok token.Token, p *ast.HashPattern, value object.Object, env *object.Environment, bind func(string, object.Object) object.Object) object.Object {
+	var hash *object.Hash
+
+	switch v := value.(type) {
+	case *object.Hash:
+		hash = v
+	case *object.Instance:
+		hash = v.Fields
+	default:
+		return newError(p.Token, "%s kalıbı için harita beklendi, %s bulundu", p.String(), value.Type())
+	}
+
+	for i, key := range p.Keys {
+		var item object.Object = NULL
+		if pair, ok := hash.GetPair(key); ok {
+			item = pair.Value
+		}
+
+		if err := destructure(tok, p.Values[i], item, env, bind); err != nil {
+			return err
+		}
+	}
+
+	if p.Rest == "" {
+		return nil
+	}
+
+	return bind(p.Rest, remainingPairs(p, hash))
+}
+
+// remainingPairs kalıpta adı geçmeyen anahtarları yeni bir haritada toplar
+func remainingPairs(p *ast.HashPattern, hash *object.Hash) *object.Hash {
+	used := map[string]bool{}
+	for _, key := range p.Keys {
+		used[key] = true
+	}
+
+	rest := &object.Hash{Token: p.Token}
+	for _, pair := range hash.OrderedPairs() {
+		if key, ok := pair.Key.(*object.String); ok && used[key.Value] {
+			continue
+		}
+
+		rest.Set(pair.Key, pair.Value)
+	}
+
+	return rest
+}
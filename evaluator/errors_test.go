@@ -0,0 +1,25 @@
+package evaluator
+
+import "testing"
+
+func TestErrorHashFields(t *testing.T) {
+	expect(t, `
+dene {
+    hata({"mesaj": "bulunamadı", "kod": 404, "yol": "/a"})
+} yakala e {
+    [e.mesaj, e.kod, e.yol, e.satır]
+}
+`, `["bulunamadı", 404, "/a", 3]`)
+
+	// yakalanıp yeniden fırlatılan hata alanlarını korur
+	expect(t, `
+f iç() { hata({"mesaj": "iç", "kod": 1}) }
+f dış() {
+    dene { iç() } yakala e { hata(e) }
+}
+dene { dış() } yakala e { [e.mesaj, e.kod, e.satır] }
+`, `["iç", 1, 2]`)
+
+	expect(t, `dene { hata("düz") } yakala e { e.anahtarlar() }`, `["mesaj", "dosya", "satır", "sütun"]`)
+	expectError(t, `hata({"kod": 1})`, `"mesaj" anahtarı bulunmalı`)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math"
+	"math/big"
 	"os"
 	"os/exec"
 	"runtime"
@@ -12,7 +13,6 @@ import (
 	"strings"
 
 	"github.com/ankalang/anka/ast"
-	"github.com/ankalang/anka/lexer"
 	"github.com/ankalang/anka/object"
 	"github.com/ankalang/anka/token"
 	"github.com/ankalang/anka/util"
@@ -27,8 +27,6 @@ var (
 )
 
 
-var lex *lexer.Lexer
-
 func init() {
 	Fns = getFns()
 	if os.Getenv("ANK_COMMAND_EXECUTOR") == "" {
@@ -43,11 +41,21 @@ func init() {
 }
 
 func newError(tok token.Token, format string, a ...interface{}) *object.Error {
-	
-	lineNum, collumn, errorLine := lex.ErrorLine(tok.Position)
+	text := fmt.Sprintf(format, a...)
+	if tok.Source == nil {
+		return &object.Error{Message: text, Text: text}
+	}
+
+	lineNum, collumn, errorLine := tok.Source.ErrorLine(tok.Position)
+	file := tok.Source.File()
 
-	errorPosition := fmt.Sprintf("\033[97m\n%d:%d> %s", lineNum,collumn,errorLine)
-	return &object.Error{Message: fmt.Sprintf(format, a...) + errorPosition}
+	location := fmt.Sprintf("%d:%d", lineNum, collumn)
+	if file != "" {
+		location = file + ":" + location
+	}
+
+	errorPosition := fmt.Sprintf("\033[97m\n%s> %s", location, errorLine)
+	return &object.Error{Message: text + errorPosition, Text: text, File: file, Line: lineNum, Column: collumn}
 }
 
 func newBreakError(tok token.Token, format string, a ...interface{}) *object.BreakError {
@@ -58,16 +66,6 @@ func newContinueError(tok token.Token, format string, a ...interface{}) *object.
 	return &object.ContinueError{Error: *newError(tok, format, a...)}
 }
 
-
-
-
-func BeginEval(program ast.Node, env *object.Environment, lexer *lexer.Lexer) object.Object {
-	
-	lex = lexer
-	
-	return Eval(program, env)
-}
-
 func Eval(node ast.Node, env *object.Environment) object.Object {
 	switch node := node.(type) {
 	
@@ -81,12 +79,20 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		return Eval(node.Expression, env)
 
 	case *ast.ReturnStatement:
-		val := Eval(node.ReturnValue, env)
+		var val object.Object
+		if node.Tail {
+			val = evalCallExpression(node.ReturnValue.(*ast.CallExpression), env, true)
+		} else {
+			val = Eval(node.ReturnValue, env)
+		}
 		if isError(val) {
 			return val
 		}
 		return &object.ReturnValue{Value: val}
 
+	case *ast.TypeStatement:
+		return evalTypeStatement(node, env)
+
 	case *ast.AssignStatement:
 		err := evalAssignment(node, env)
 
@@ -97,6 +103,15 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		return NULL
 	
 	case *ast.NumberLiteral:
+		if node.Integer != nil {
+			return object.NewBigInteger(node.Token, node.Integer)
+		}
+
+		if node.Token.Type == token.DECIMAL {
+			d, _ := parseDecimal(node.Token, node.Token.Literal)
+			return d
+		}
+
 		return &object.Number{Token: node.Token, Value: node.Value}
 
 	case *ast.NullLiteral:
@@ -127,6 +142,9 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	case *ast.IfExpression:
 		return evalIfExpression(node, env)
 
+	case *ast.MatchExpression:
+		return evalMatchExpression(node, env)
+
 	case *ast.WhileExpression:
 		return evalWhileExpression(node, env)
 
@@ -144,9 +162,12 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		body := node.Body
 		name := node.Name
 		fn := &object.Function{Token: node.Token, Parameters: params, Env: env, Body: body, Name: name, Node: node}
+		env.Capture()
 
 		if name != "" {
-			env.Set(name, fn)
+			if err := env.Scope().Declare(name, fn, false); err != nil {
+				return newError(node.Token, "%s", err.Error())
+			}
 		}
 
 		return fn
@@ -155,32 +176,7 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		return evalDecorator(node, env)
 
 	case *ast.CallExpression:
-		function := Eval(node.Function, env)
-		if isError(function) {
-			return function
-		}
-
-		args := evalExpressions(node.Arguments, env)
-
-		
-		
-		
-		
-		
-		if len(args) > 0 {
-			firstArg, ok := args[0].(*object.Array)
-
-			if ok && firstArg.IsCurrentArgs {
-				newArgs := env.CurrentArgs
-				args = append(newArgs, args[1:]...)
-			}
-		}
-
-		if len(args) == 1 && isError(args[0]) {
-			return args[0]
-		}
-
-		return applyFunction(node.Token, function, env, args)
+		return evalCallExpression(node, env, false)
 
 	case *ast.MethodExpression:
 		o := Eval(node.Object, env)
@@ -193,7 +189,12 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 			return args[0]
 		}
 
-		return applyMethod(node.Token, o, node, env, args)
+		keywords, err := evalKeywordArguments(node.Keywords, env)
+		if err != nil {
+			return err
+		}
+
+		return applyMethod(node.Token, o, node.Method.String(), node.Optional, env, args, keywords)
 
 	case *ast.PropertyExpression:
 		return evalPropertyExpression(node, env)
@@ -214,6 +215,9 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	case *ast.CommandExpression:
 		return evalCommandExpression(node.Token, node.Value, env)
 
+	case *ast.TryExpression:
+		return evalTryExpression(node, env)
+
 	
 	
 	
@@ -314,13 +318,16 @@ func evalCompoundAssignment(node *ast.CompoundAssignment, env *object.Environmen
 		op = op[:len(op)-1]
 	}
 	
-	expr := evalInfixExpression(node.Token, op, node.Left, node.Right, env)
+	expr := evalInfixOperation(node.Token, op, left, right)
 	if isError(expr) {
 		return expr
 	}
 	switch nodeLeft := node.Left.(type) {
 	case *ast.Identifier:
-		env.Set(nodeLeft.String(), expr)
+		if err := env.Assign(nodeLeft.String(), expr); err != nil {
+			return newError(node.Token, "%s", err.Error())
+		}
+
 		return NULL
 	case *ast.IndexExpression:
 		
@@ -341,7 +348,10 @@ func evalDecorator(node *ast.Decorator, env *object.Environment) object.Object {
 		return err
 	}
 
-	env.Set(ident, fn)
+	if err := env.Scope().Declare(ident, fn, false); err != nil {
+		return newError(node.Token, "%s", err.Error())
+	}
+
 	return object.NULL
 }
 
@@ -369,6 +379,7 @@ func doEvalDecorator(node *ast.Decorator, env *object.Environment) (string, obje
 	case *ast.FunctionLiteral:
 		
 		fn := &object.Function{Token: decorated.Token, Parameters: decorated.Parameters, Env: env, Body: decorated.Body, Name: name, Node: decorated}
+		env.Capture()
 		return name, applyFunction(decorated.Token, decorator, env, []object.Object{fn}), nil
 	case *ast.Decorator:
 		
@@ -402,35 +413,26 @@ func getDecoratedName(decorated ast.Expression) (string, bool) {
 
 
 func evalIndexAssignment(iex *ast.IndexExpression, expr object.Object, env *object.Environment) object.Object {
-	leftObj := Eval(iex.Left, env)
-	index := Eval(iex.Index, env)
+	return assignIndex(iex.Token, Eval(iex.Left, env), Eval(iex.Index, env), expr)
+}
+
+func assignIndex(tok token.Token, leftObj, index, expr object.Object) object.Object {
 	if leftObj.Type() == object.ARRAY_OBJ {
 		arrayObject := leftObj.(*object.Array)
 		idx := index.(*object.Number).Int()
-		elems := arrayObject.Elements
 		if idx < 0 {
-			return newError(iex.Token, "Verilen dizin aralık dışı: %d", idx)
-		}
-		if idx >= len(elems) {
-			
-			for i := len(elems); i <= idx; i++ {
-				elems = append(elems, NULL)
-			}
-			arrayObject.Elements = elems
+			return newError(tok, "Verilen dizin aralık dışı: %d", idx)
 		}
-		elems[idx] = expr
+		arrayObject.SetAt(idx, expr, NULL)
 		return NULL
 	}
 	if leftObj.Type() == object.HASH_OBJ {
 		hashObject := leftObj.(*object.Hash)
-		key, ok := index.(object.Hashable)
+		_, ok := index.(object.Hashable)
 		if !ok {
-			return newError(iex.Token, "harita anahtarları olarak: Sayılar, fonksiyonlar ve değişkenler kullanılamaz.")
-			
+			return newError(tok, "Harita anahtarı olarak %s kullanılamaz", index.Type())
 		}
-		hashed := key.HashKey()
-		pair := object.HashPair{Key: index, Value: expr}
-		hashObject.Pairs[hashed] = pair
+		hashObject.Set(index, expr)
 		return NULL
 	}
 	return NULL
@@ -438,28 +440,64 @@ func evalIndexAssignment(iex *ast.IndexExpression, expr object.Object, env *obje
 
 
 func evalPropertyAssignment(pex *ast.PropertyExpression, expr object.Object, env *object.Environment) object.Object {
-	leftObj := Eval(pex.Object, env)
+	return assignProperty(pex.Token, Eval(pex.Object, env), pex.Property.String(), expr)
+}
+
+func assignProperty(tok token.Token, leftObj object.Object, property string, expr object.Object) object.Object {
 	if leftObj.Type() == object.HASH_OBJ {
 		hashObject := leftObj.(*object.Hash)
-		prop := &object.String{Token: pex.Token, Value: pex.Property.String()}
-		hashed := prop.HashKey()
-		pair := object.HashPair{Key: prop, Value: expr}
-		hashObject.Pairs[hashed] = pair
+		prop := &object.String{Token: tok, Value: property}
+		hashObject.Set(prop, expr)
 		return NULL
 	}
-	return newError(pex.Token, "sadece haritaların anahtarlarına değer atamaları yapılabilir")
+	if instance, ok := leftObj.(*object.Instance); ok {
+		if !instance.Of.HasField(property) {
+			return newError(tok, "%s tipinin '%s' adında bir alanı yok", instance.Of.Name, property)
+		}
+
+		instance.Fields.Set(&object.String{Token: tok, Value: property}, expr)
+		return NULL
+	}
+	return newError(tok, "sadece haritaların anahtarlarına değer atamaları yapılabilir")
+}
+
+// bindName bildirimlerde adı bu kapsamda tanımlar, düz atamalarda adın
+// tanımlandığı kapsamdaki değeri günceller
+func bindName(as *ast.AssignStatement, env *object.Environment, name string, val object.Object) object.Object {
+	var err error
+
+	if as.Declaration != nil {
+		err = env.Declare(name, val, as.Declaration.Type == token.CONST)
+	} else {
+		err = env.Assign(name, val)
+	}
+
+	if err != nil {
+		return newError(as.Token, "%s", err.Error())
+	}
+
+	return nil
 }
 
 func evalAssignment(as *ast.AssignStatement, env *object.Environment) object.Object {
-	val := Eval(as.Value, env)
-	if isError(val) {
-		return val
+	var val object.Object = NULL
+
+	if as.Value != nil {
+		val = Eval(as.Value, env)
+		if isError(val) {
+			return val
+		}
 	}
 
 	
 	if as.Name != nil {
-		env.Set(as.Name.Value, val)
-		return nil
+		return bindName(as, env, as.Name.Value, val)
+	}
+
+	if as.Pattern != nil {
+		return destructure(as.Token, as.Pattern, val, env, func(name string, val object.Object) object.Object {
+			return bindName(as, env, name, val)
+		})
 	}
 
 	
@@ -468,21 +506,24 @@ func evalAssignment(as *ast.AssignStatement, env *object.Environment) object.Obj
 		case *object.Array:
 			elements := v.Elements
 			for i, name := range as.Names {
+				var element object.Object = NULL
 				if i < len(elements) {
-					env.Set(name.String(), elements[i])
-					continue
+					element = elements[i]
 				}
 
-				env.Set(name.String(), NULL)
+				if err := bindName(as, env, name.String(), element); err != nil {
+					return err
+				}
 			}
 		case *object.Hash:
 			for _, name := range as.Names {
-				x, ok := v.GetPair(name.String())
+				var value object.Object = NULL
+				if x, ok := v.GetPair(name.String()); ok {
+					value = x.Value
+				}
 
-				if ok {
-					env.Set(name.String(), x.Value)
-				} else {
-					env.Set(name.String(), NULL)
+				if err := bindName(as, env, name.String(), value); err != nil {
+					return err
 				}
 			}
 		default:
@@ -560,15 +601,28 @@ func evalInfixExpression(
 		return right
 	}
 
+	return evalInfixOperation(tok, operator, left, right)
+}
+
+// evalInfixOperation iki tarafı hesaplanmış bir operatörü uygular
+func evalInfixOperation(tok token.Token, operator string, left, right object.Object) object.Object {
 	switch {
 	case left.Type() == object.NUMBER_OBJ && right.Type() == object.NUMBER_OBJ:
 		return evalNumberInfixExpression(tok, operator, left, right)
+	case isDecimalOperand(left, right):
+		return evalDecimalInfixExpression(tok, operator, left, right)
+	case left.Type() == object.SET_OBJ && right.Type() == object.SET_OBJ:
+		return evalSetInfixExpression(tok, operator, left, right)
+	case left.Type() == object.BYTES_OBJ && (right.Type() == object.BYTES_OBJ || right.Type() == object.STRING_OBJ):
+		return evalBytesInfixExpression(tok, operator, left, right)
 	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
 		return evalStringInfixExpression(tok, operator, left, right)
 	case left.Type() == object.ARRAY_OBJ && right.Type() == object.ARRAY_OBJ:
 		return evalArrayInfixExpression(tok, operator, left, right)
 	case left.Type() == object.HASH_OBJ && right.Type() == object.HASH_OBJ:
 		return evalHashInfixExpression(tok, operator, left, right)
+	case left.Type() == object.TIME_OBJ && (right.Type() == object.TIME_OBJ || right.Type() == object.NUMBER_OBJ):
+		return evalTimeInfixExpression(tok, operator, left, right)
 	case operator == "in":
 		return evalInExpression(tok, left, right)
 	case operator == "!in":
@@ -595,6 +649,10 @@ func evalBangOperatorExpression(right object.Object) object.Object {
 func evalTildePrefixOperatorExpression(tok token.Token, right object.Object) object.Object {
 	switch o := right.(type) {
 	case *object.Number:
+		if o.Integer {
+			return object.NewBigInteger(tok, new(big.Int).Not(o.BigInt()))
+		}
+
 		return &object.Number{Value: float64(^int64(o.Value))}
 	default:
 		return newError(tok, "(~) sadece sayılarda kullanılabilir fakat %s bulundu. (%s) ", o.Type(), o.Inspect())
@@ -602,16 +660,24 @@ func evalTildePrefixOperatorExpression(tok token.Token, right object.Object) obj
 }
 
 func evalMinusPrefixOperatorExpression(tok token.Token, right object.Object) object.Object {
+	if d, ok := right.(*object.Decimal); ok {
+		return &object.Decimal{Token: tok, Unscaled: new(big.Int).Neg(d.Unscaled), Scale: d.Scale}
+	}
+
 	if right.Type() != object.NUMBER_OBJ {
 		return newError(tok, "Bilinmeyen operatör: -%s", right.Type())
 	}
 
-	value := right.(*object.Number).Value
-	return &object.Number{Value: -value}
+	number := right.(*object.Number)
+	if number.Integer {
+		return object.NewBigInteger(tok, new(big.Int).Neg(number.BigInt()))
+	}
+
+	return &object.Number{Value: -number.Value}
 }
 
 func evalPlusPrefixOperatorExpression(tok token.Token, right object.Object) object.Object {
-	if right.Type() != object.NUMBER_OBJ {
+	if right.Type() != object.NUMBER_OBJ && right.Type() != object.DECIMAL_OBJ {
 		return newError(tok, "Bilinmeyen operatör: +%s", right.Type())
 	}
 
@@ -622,8 +688,16 @@ func evalNumberInfixExpression(
 	tok token.Token, operator string,
 	left, right object.Object,
 ) object.Object {
-	leftVal := left.(*object.Number).Value
-	rightVal := right.(*object.Number).Value
+	leftNum, rightNum := left.(*object.Number), right.(*object.Number)
+
+	if leftNum.Integer && rightNum.Integer && operator != ".." {
+		if result, ok := evalIntegerInfixExpression(tok, operator, leftNum, rightNum); ok {
+			return result
+		}
+	}
+
+	leftVal := leftNum.Value
+	rightVal := rightNum.Value
 	switch operator {
 	case "+":
 		return &object.Number{Token: tok, Value: leftVal + rightVal}
@@ -678,13 +752,21 @@ func evalNumberInfixExpression(
 	case "..":
 		a := make([]object.Object, 0)
 
+		element := func(i float64) object.Object {
+			if leftNum.Integer {
+				return truncateToInteger(tok, i)
+			}
+
+			return &object.Number{Token: tok, Value: i}
+		}
+
 		if leftVal <= rightVal {
 			for i := leftVal; i <= rightVal; i++ {
-				a = append(a, &object.Number{Token: tok, Value: float64(i)})
+				a = append(a, element(i))
 			}
 		} else {
 			for i := leftVal; i >= rightVal; i-- {
-				a = append(a, &object.Number{Token: tok, Value: float64(i)})
+				a = append(a, element(i))
 			}
 		}
 
@@ -789,14 +871,14 @@ func evalHashInfixExpression(
 	leftHashObject := left.(*object.Hash)
 	rightHashObject := right.(*object.Hash)
 	if operator == "+" {
-		leftVal := leftHashObject.Pairs
-		rightVal := rightHashObject.Pairs
-		for _, rightPair := range rightVal {
-			key := rightPair.Key
-			hashed := key.(object.Hashable).HashKey()
-			leftVal[hashed] = object.HashPair{Key: key, Value: rightPair.Value}
+		hash := &object.Hash{Token: tok}
+		for _, pair := range leftHashObject.OrderedPairs() {
+			hash.Set(pair.Key, pair.Value)
 		}
-		return &object.Hash{Token: tok, Pairs: leftVal}
+		for _, pair := range rightHashObject.OrderedPairs() {
+			hash.Set(pair.Key, pair.Value)
+		}
+		return hash
 	}
 
 	return newError(tok, "Bilinmeyen operatör: %s %s %s", left.Type(), operator, right.Type())
@@ -833,9 +915,17 @@ func evalInExpression(tok token.Token, left, right object.Object) object.Object
 			found = strings.Contains(right.Inspect(), left.Inspect())
 		}
 	case *object.Hash:
-		if left.Type() == object.STRING_OBJ {
-			_, ok := rightObj.GetPair(left.(*object.String).Value)
-			found = ok
+		if key, ok := left.(object.Hashable); ok {
+			_, found = rightObj.Get(key.HashKey())
+		}
+	case *object.Set:
+		found = rightObj.Has(left)
+	case *object.Bytes:
+		switch needle := left.(type) {
+		case *object.Bytes:
+			found = bytes.Contains(rightObj.Value, needle.Value)
+		case *object.Number:
+			found = needle.IsInt() && needle.Value >= 0 && needle.Value <= 255 && bytes.IndexByte(rightObj.Value, byte(needle.Value)) != -1
 		}
 	default:
 		return newError(tok, "'de' operatörü, %s için geçerli değil", right.Type())
@@ -862,32 +952,107 @@ func evalIfExpression(
 		}
 
 		if isTruthy(condition) {
-			return Eval(scenario.Consequence, env)
+			return Eval(scenario.Consequence, object.NewBlockEnvironment(env))
 		}
 	}
 
 	return NULL
 }
 
+func evalTryExpression(
+	te *ast.TryExpression,
+	env *object.Environment,
+) object.Object {
+	result := Eval(te.Block, object.NewBlockEnvironment(env))
+
+	if e, ok := result.(*object.Error); ok && te.Catch != nil {
+		catchEnv := object.NewBlockEnvironment(env)
+		if te.Identifier != "" {
+			catchEnv.Set(te.Identifier, errorToHash(e))
+		}
+
+		result = Eval(te.Catch, catchEnv)
+	}
+
+	if te.Finally != nil {
+		finally := Eval(te.Finally, object.NewBlockEnvironment(env))
+
+		if isError(finally) || (finally != nil && finally.Type() == object.RETURN_VALUE_OBJ) {
+			return finally
+		}
+	}
+
+	if result == nil {
+		return NULL
+	}
+
+	return result
+}
+
+
+func errorToHash(e *object.Error) *object.Hash {
+	text := e.Text
+	if text == "" {
+		text = e.Message
+	}
+
+	hash := hashFromEntries(token.Token{}, []hashEntry{
+		{"mesaj", &object.String{Value: text}},
+		{"dosya", &object.String{Value: e.File}},
+		{"satır", object.NewInteger(token.Token{}, int64(e.Line))},
+		{"sütun", object.NewInteger(token.Token{}, int64(e.Column))},
+	})
+
+	// hata(harita) ile verilen alanlar korunur; aynı adlı alanlarda
+	// kullanıcının verdiği değer geçerlidir
+	if e.Data != nil {
+		for _, pair := range e.Data.OrderedPairs() {
+			hash.Set(pair.Key, pair.Value)
+		}
+	}
+
+	return hash
+}
+
+type hashEntry struct {
+	key   string
+	value object.Object
+}
+
+func hashFromEntries(tok token.Token, entries []hashEntry) *object.Hash {
+	hash := &object.Hash{Token: tok}
+
+	for _, entry := range entries {
+		hash.Set(&object.String{Token: tok, Value: entry.key}, entry.value)
+	}
+
+	return hash
+}
+
 func evalWhileExpression(
 	we *ast.WhileExpression,
 	env *object.Environment,
 ) object.Object {
-	condition := Eval(we.Condition, env)
-	if isError(condition) {
-		return condition
-	}
+	for {
+		condition := Eval(we.Condition, env)
+		if isError(condition) {
+			return condition
+		}
+
+		if !isTruthy(condition) {
+			return NULL
+		}
 
-	if isTruthy(condition) {
-		evaluated := Eval(we.Consequence, env)
+		evaluated := Eval(we.Consequence, object.NewBlockEnvironment(env))
 
-		if isError(evaluated) {
+		switch evaluated.(type) {
+		case *object.BreakError:
+			return NULL
+		case *object.ContinueError:
+		case *object.Error, *object.ReturnValue:
 			return evaluated
 		}
-
-		evalWhileExpression(we, env)
 	}
-	return NULL
 }
 
 
@@ -895,38 +1060,40 @@ func evalForExpression(
 	fe *ast.ForExpression,
 	env *object.Environment,
 ) object.Object {
-	
-	
-	existingIdentifier, identifierExisted := env.Get(fe.Identifier)
+	// döngü değişkeni döngüye ait kapsamda tanımlanır, dışarı sızmaz
+	loopEnv := object.NewBlockEnvironment(env)
 
-	
-	err := Eval(fe.Starter, env)
-	if isError(err) {
-		return err
+	starter := fe.Starter.(*ast.AssignStatement)
+	initial := Eval(starter.Value, loopEnv)
+	if isError(initial) {
+		return initial
 	}
 
-	
-	holds := true
+	loopEnv.Set(fe.Identifier, initial)
 
-	defer func() {
-		if identifierExisted {
-			env.Set(fe.Identifier, existingIdentifier)
-		} else {
-			env.Delete(fe.Identifier)
-		}
-	}()
+	var iterationEnv *object.Environment
+	holds := true
 
 	
 	for holds {
 		
-		evaluated := Eval(fe.Condition, env)
+		evaluated := Eval(fe.Condition, loopEnv)
 		if isError(evaluated) {
 			return evaluated
 		}
 
 		
 		if isTruthy(evaluated) {
-			res := Eval(fe.Block, env)
+			// her tur değişkenin kendi kopyasıyla çalışır, böylece gövdedeki
+			// fonksiyonlar o turdaki değeri yakalar. Fonksiyon oluşturmayan
+			// turların kapsamı boşaltılıp sonraki turda yeniden kullanılır.
+			if iterationEnv == nil || !iterationEnv.Reset() {
+				iterationEnv = object.NewBlockEnvironment(loopEnv)
+			}
+			current, _ := loopEnv.Get(fe.Identifier)
+			iterationEnv.Set(fe.Identifier, current)
+
+			res := Eval(fe.Block, iterationEnv)
 			if isError(res) {
 				
 				switch res.(type) {
@@ -947,7 +1114,10 @@ func evalForExpression(
 				
 			}
 
-			err = Eval(fe.Closer, env)
+			current, _ = iterationEnv.Get(fe.Identifier)
+			loopEnv.Set(fe.Identifier, current)
+
+			err := Eval(fe.Closer, loopEnv)
 			if isError(err) {
 				return err
 			}
@@ -968,42 +1138,38 @@ func evalForInExpression(
 	env *object.Environment,
 ) object.Object {
 	iterable := Eval(fie.Iterable, env)
-	
-	
-	existingKeyIdentifier, okk := env.Get(fie.Key)
-	existingValueIdentifier, okv := env.Get(fie.Value)
 
-	
-	
-	defer func() {
-		if okk {
-			env.Set(fie.Key, existingKeyIdentifier)
-		} else {
-			env.Delete(fie.Key)
-		}
+	next, reset, err := iterate(fie.Token, iterable, env)
+	if err != nil {
+		return err
+	}
 
-		if okv {
-			env.Set(fie.Value, existingValueIdentifier)
-		} else {
-			env.Delete(fie.Value)
-		}
-	}()
+	defer reset()
+
+	return loopIterable(next, env, fie, 0)
+}
 
+// iterate değerin döngüde nasıl dolaşılacağını döner; next nil anahtar ya da
+// EOF döndüğünde döngü biter, değer olarak bir hata dönerse döngü o hatayla
+// biter. reset döngüden çıkıldığında çağrılır. Her
+// döngü kendi imlecini alır, değerin kendisi değişmez.
+func iterate(tok token.Token, iterable object.Object, env *object.Environment) (next func() (object.Object, object.Object), reset func(), err object.Object) {
 	switch i := iterable.(type) {
 	case object.Iterable:
-		defer func() {
-			i.Reset()
-		}()
-
-		return loopIterable(i.Next, env, fie, 0)
+		return i.Iterator(), func() {}, nil
 	case *object.Builtin:
 		if i.Next == nil {
-			return newError(fie.Token, "yerleşik fonksiyon dögüde kullanılmaz.")
+			return nil, nil, newError(tok, "yerleşik fonksiyon dögüde kullanılmaz.")
+		}
+
+		reset := func() {}
+		if i.Close != nil {
+			reset = i.Close
 		}
 
-		return loopIterable(i.Next, env, fie, 0)
+		return func() (object.Object, object.Object) { return i.Next(env) }, reset, nil
 	default:
-		return newError(fie.Token, "'%s' %s tipine sahip ve yenilenebilir değil. ", i.Inspect(), i.Type())
+		return nil, nil, newError(tok, "'%s' %s tipine sahip ve yenilenebilir değil. ", i.Inspect(), i.Type())
 	}
 }
 
@@ -1011,14 +1177,19 @@ func loopIterable(next func() (object.Object, object.Object), env *object.Enviro
 	
 	k, v := next()
 
-	
+	var iterationEnv *object.Environment
 	
 	for k != nil && v != EOF {
 		
 		
-		env.Set(fie.Key, k)
-		env.Set(fie.Value, v)
-		res := Eval(fie.Block, env)
+		// anahtar ve değer her turda yeni bir blok kapsamında tanımlanır;
+		// kapsamı fonksiyon yakalamadıysa boşaltılıp yeniden kullanılır
+		if iterationEnv == nil || !iterationEnv.Reset() {
+			iterationEnv = object.NewBlockEnvironment(env)
+		}
+		iterationEnv.Set(fie.Key, k)
+		iterationEnv.Set(fie.Value, v)
+		res := Eval(fie.Block, iterationEnv)
 
 		if isError(res) {
 			
@@ -1046,6 +1217,10 @@ func loopIterable(next func() (object.Object, object.Object), env *object.Enviro
 		k, v = next()
 	}
 
+	if err, ok := v.(*object.Error); ok && v != EOF {
+		return err
+	}
+
 	if k == nil || v == EOF {
 		
 		
@@ -1062,15 +1237,19 @@ func evalIdentifier(
 	node *ast.Identifier,
 	env *object.Environment,
 ) object.Object {
-	if val, ok := env.Get(node.Value); ok {
+	return lookupName(node.Token, node.Value, env)
+}
+
+func lookupName(tok token.Token, name string, env *object.Environment) object.Object {
+	if val, ok := env.Get(name); ok {
 		return val
 	}
 
-	if builtin, ok := Fns[node.Value]; ok {
+	if builtin, ok := Fns[name]; ok {
 		return builtin
 	}
 
-	return newError(node.Token, "Bulunamadı: "+node.Value)
+	return newError(tok, "Bulunamadı: "+name)
 }
 func isTruthy(obj object.Object) bool {
 	switch v := obj.(type) {
@@ -1083,6 +1262,8 @@ func isTruthy(obj object.Object) bool {
 	
 	case *object.Number:
 		return v.Value != v.ZeroValue()
+	case *object.Decimal:
+		return v.Unscaled.Sign() != 0
 	
 	
 	case *object.String:
@@ -1121,10 +1302,14 @@ func evalPropertyExpression(pe *ast.PropertyExpression, env *object.Environment)
 		return o
 	}
 
+	return evalPropertyOperation(pe.Token, o, pe.Property.String(), pe.Optional)
+}
+
+func evalPropertyOperation(tok token.Token, o object.Object, property string, optional bool) object.Object {
 	switch obj := o.(type) {
 	case *object.String:
 		
-		if pe.Property.String() == "ok" {
+		if property == "ok" {
 			if obj.Ok != nil {
 				return obj.Ok
 			}
@@ -1132,7 +1317,7 @@ func evalPropertyExpression(pe *ast.PropertyExpression, env *object.Environment)
 			return FALSE
 		}
 		
-		if pe.Property.String() == "done" {
+		if property == "done" {
 			if obj.Done != nil {
 				return obj.Done
 			}
@@ -1140,37 +1325,103 @@ func evalPropertyExpression(pe *ast.PropertyExpression, env *object.Environment)
 			return FALSE
 		}
 	case *object.Hash:
-		return evalHashIndexExpression(obj.Token, obj, &object.String{Token: pe.Token, Value: pe.Property.String()})
+		return evalHashIndexExpression(obj.Token, obj, &object.String{Token: tok, Value: property})
+	case *object.Time:
+		if value, ok := timeProperty(tok, obj.Value, property); ok {
+			return value
+		}
+	case *object.Instance:
+		if value, ok := instanceMember(obj, property); ok {
+			return value
+		}
+
+		if !optional {
+			return newError(tok, "'%s' özelliği, %s tipinde geçersizdir.", property, obj.Of.Name)
+		}
 	}
 
-	if pe.Optional {
+	if optional {
 		return NULL
 	}
 
-	return newError(pe.Token, "'%s' özelliği, %s tipinde geçersizdir.", pe.Property.String(), o.Type())
+	return newError(tok, "'%s' özelliği, %s tipinde geçersizdir.", property, o.Type())
+}
+
+// evalCallExpression çağrıyı yapar; kuyruk konumundaki çağrılar yapılmadan
+// çağıran fonksiyona döner
+func evalCallExpression(node *ast.CallExpression, env *object.Environment, tail bool) object.Object {
+	function := Eval(node.Function, env)
+	if isError(function) {
+		return function
+	}
+
+	args := evalExpressions(node.Arguments, env)
+
+	
+	
+	
+	
+	
+	if len(args) > 0 {
+		firstArg, ok := args[0].(*object.Array)
+
+		if ok && firstArg.IsCurrentArgs {
+			newArgs := env.CurrentArgs
+			args = append(newArgs, args[1:]...)
+		}
+	}
+
+	if len(args) == 1 && isError(args[0]) {
+		return args[0]
+	}
+
+	keywords, err := evalKeywordArguments(node.Keywords, env)
+	if err != nil {
+		return err
+	}
+
+	if tail {
+		return &tailCall{tok: node.Token, fn: function, env: env, args: args, keywords: keywords}
+	}
+
+	return callFunction(node.Token, function, env, args, keywords)
 }
 
 func applyFunction(tok token.Token, fn object.Object, env *object.Environment, args []object.Object) object.Object {
+	return callFunction(tok, fn, env, args, nil)
+}
+
+// callFunction fonksiyonu sıralı ve isimli argümanlarla çağırır
+func callFunction(tok token.Token, fn object.Object, env *object.Environment, args []object.Object, keywords []object.KeywordArgument) object.Object {
+	if _, ok := fn.(*object.Builtin); ok && len(keywords) > 0 {
+		return newError(keywords[0].Token, "yerleşik fonksiyonlar isimli argüman almaz")
+	}
+
 	switch fn := fn.(type) {
 	case *object.Function:
-		extendedEnv, err := extendFunctionEnv(fn, args)
-
-		if err != nil {
-			return err
-		}
-		evaluated := Eval(fn.Body, extendedEnv)
-		return unwrapReturnValue(evaluated)
+		return applyUserFunction(tok, fn, env, args, keywords)
 
 	case *object.Builtin:
 		return fn.Fn(tok, env, args...)
 
+	case *object.TypeDef:
+		return instantiate(tok, fn, env, args, keywords)
+
+	case *object.Closure:
+		return fn.Machine.RunClosure(tok, fn, env, args, keywords)
+
 	default:
 		return newError(tok, "bir fonksiyon değil: %s", fn.Type())
 	}
 }
 
-func applyMethod(tok token.Token, o object.Object, me *ast.MethodExpression, env *object.Environment, args []object.Object) object.Object {
-	method := me.Method.String()
+func applyMethod(tok token.Token, o object.Object, method string, optional bool, env *object.Environment, args []object.Object, keywords []object.KeywordArgument) object.Object {
+
+	if instance, ok := o.(*object.Instance); ok {
+		if member, ok := instanceMember(instance, method); ok {
+			return callFunction(tok, member, env, args, keywords)
+		}
+	}
 	
 	
 	hash, isHash := o.(*object.Hash)
@@ -1178,14 +1429,14 @@ func applyMethod(tok token.Token, o object.Object, me *ast.MethodExpression, env
 	
 	if isHash && hash.GetKeyType(method) == object.FUNCTION_OBJ {
 		pair, _ := hash.GetPair(method)
-		return applyFunction(tok, pair.Value.(*object.Function), env, args)
+		return callFunction(tok, pair.Value, env, args, keywords)
 	}
 
 	
 	f, ok := Fns[method]
 
 	if !ok {
-		if me.Optional {
+		if optional {
 			return NULL
 		}
 
@@ -1197,6 +1448,10 @@ func applyMethod(tok token.Token, o object.Object, me *ast.MethodExpression, env
 		return newError(tok, "'%s()' metodu, '%s' üzerinde çağrılamaz.", method, o.Type())
 	}
 
+	if len(keywords) > 0 {
+		return newError(keywords[0].Token, "yerleşik fonksiyonlar isimli argüman almaz")
+	}
+
 	
 	args = append([]object.Object{o}, args...)
 	return f.Fn(tok, env, args...)
@@ -1204,24 +1459,59 @@ func applyMethod(tok token.Token, o object.Object, me *ast.MethodExpression, env
 
 func extendFunctionEnv(
 	fn *object.Function,
+	call *object.Call,
 	args []object.Object,
+	keywords []object.KeywordArgument,
 ) (*object.Environment, *object.Error) {
 	env := object.NewEnclosedEnvironment(fn.Env, args)
+	env.Call = call
+
+	named, err := namedArguments(fn, args, keywords)
+	if err != nil {
+		return nil, err
+	}
 
 	for paramIdx, param := range fn.Parameters {
+		if param.Rest {
+			rest := []object.Object{}
+			if len(args) > paramIdx {
+				rest = append(rest, args[paramIdx:]...)
+			}
+
+			env.Set(param.Value, &object.Array{Token: param.Token, Elements: rest})
+			continue
+		}
+
+		namedArg, namePassed := named[param.Value]
 		argumentPassed := len(args) > paramIdx
 
-		if !argumentPassed && param.Default == nil {
-			return nil, newError(fn.Token, "%s fonksiyonu için %s argümanı bulunamadı.", param.Value, fn.Inspect())
+		if !argumentPassed && !namePassed && param.Default == nil {
+			return nil, newError(fn.Token, "%s fonksiyonu için %s argümanı bulunamadı.", functionName(fn), param.Value)
 		}
 
 		var arg object.Object
-		if argumentPassed {
+		switch {
+		case argumentPassed:
 			arg = args[paramIdx]
-		} else {
+		case namePassed:
+			arg = namedArg
+		default:
 			arg = Eval(param.Default, env)
 		}
 
+		if param.Pattern != nil {
+			err := destructure(param.Token, param.Pattern, arg, env, func(name string, val object.Object) object.Object {
+				env.Set(name, val)
+				return nil
+			})
+
+			if err != nil {
+				return nil, err.(*object.Error)
+			}
+
+			continue
+		}
+
 		env.Set(param.Value, arg)
 	}
 
@@ -1251,13 +1541,19 @@ func evalIndexExpression(node *ast.IndexExpression, env *object.Environment) obj
 		return end
 	}
 
+	return evalIndexOperation(tok, left, index, end, node.IsRange)
+}
+
+func evalIndexOperation(tok token.Token, left, index, end object.Object, isRange bool) object.Object {
 	switch {
 	case left.Type() == object.ARRAY_OBJ && index.Type() == object.NUMBER_OBJ:
-		return evalArrayIndexExpression(tok, left, index, end, node.IsRange)
-	case left.Type() == object.HASH_OBJ && index.Type() == object.STRING_OBJ:
+		return evalArrayIndexExpression(tok, left, index, end, isRange)
+	case left.Type() == object.HASH_OBJ:
 		return evalHashIndexExpression(tok, left, index)
 	case left.Type() == object.STRING_OBJ && index.Type() == object.NUMBER_OBJ:
-		return evalStringIndexExpression(tok, left, index, end, node.IsRange)
+		return evalStringIndexExpression(tok, left, index, end, isRange)
+	case left.Type() == object.BYTES_OBJ && index.Type() == object.NUMBER_OBJ:
+		return evalBytesIndexExpression(tok, left, index, end, isRange)
 	default:
 		return newError(tok, "dizin operatörü bu tip için geçersiz")
 	}
@@ -1385,17 +1681,17 @@ func evalHashLiteral(
 	node *ast.HashLiteral,
 	env *object.Environment,
 ) object.Object {
-	pairs := make(map[object.HashKey]object.HashPair)
+	hash := &object.Hash{Token: node.Token}
 
-	for keyNode, valueNode := range node.Pairs {
+	for _, keyNode := range node.Keys {
+		valueNode := node.Pairs[keyNode]
 		key := Eval(keyNode, env)
 		if isError(key) {
 			return key
 		}
 
-		hashKey, ok := key.(object.Hashable)
-		if !ok {
-			return newError(node.Token, "Harita anahtarları olarak; sayılar, değişkenler ve fonksiyonlar kullanılamaz.")
+		if _, ok := key.(object.Hashable); !ok {
+			return newError(node.Token, "Harita anahtarı olarak %s kullanılamaz", key.Type())
 		}
 
 		value := Eval(valueNode, env)
@@ -1403,11 +1699,10 @@ func evalHashLiteral(
 			return value
 		}
 
-		hashed := hashKey.HashKey()
-		pairs[hashed] = object.HashPair{Key: key, Value: value}
+		hash.Set(key, value)
 	}
 
-	return &object.Hash{Pairs: pairs}
+	return hash
 }
 
 func evalHashIndexExpression(tok token.Token, hash, index object.Object) object.Object {
@@ -1418,7 +1713,7 @@ func evalHashIndexExpression(tok token.Token, hash, index object.Object) object.
 		return newError(tok, "Harita anahtarı olarak %s kullanılamaz", index.Type())
 	}
 
-	pair, ok := hashObject.Pairs[key.HashKey()]
+	pair, ok := hashObject.Get(key.HashKey())
 	if !ok {
 		return NULL
 	}
@@ -1432,7 +1727,11 @@ func evalCommandExpression(tok token.Token, cmd string, env *object.Environment)
 	
 	cmd = util.InterpolateStringVars(cmd, env)
 
-	
+	return runCommand(tok, cmd)
+}
+
+// runCommand değişkenleri yerine konmuş komutu çalıştırır
+func runCommand(tok token.Token, cmd string) object.Object {
 	background := len(cmd) > 1 && cmd[len(cmd)-1] == '&'
 	
 	
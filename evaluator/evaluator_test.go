@@ -0,0 +1,65 @@
+package evaluator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ankalang/anka/lexer"
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/parser"
+)
+
+// run kodu yeni bir yorumlayıcıyla çalıştırır, sonucu ve eko çıktısını döner
+func run(t *testing.T, code string) (object.Object, string) {
+	t.Helper()
+
+	p := parser.New(lexer.New(code))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		t.Fatalf("ayrıştırıcı hatası: %s", strings.Join(p.Errors(), "\n"))
+	}
+
+	var out bytes.Buffer
+	env := object.NewEnvironment(&out, ".", "test")
+	result := NewInterpreter(strings.NewReader("")).Run(program, env)
+
+	return result, out.String()
+}
+
+// expect kodun sonucunun Inspect değerine bakar
+func expect(t *testing.T, code string, want string) {
+	t.Helper()
+
+	result, _ := run(t, code)
+	if result == nil {
+		t.Fatalf("%s: sonuç yok", code)
+	}
+
+	if got := result.Inspect(); got != want {
+		t.Errorf("%s\nbulunan: %s\nistenen: %s", code, got, want)
+	}
+}
+
+// expectError kodun verilen mesajı içeren bir hatayla bittiğine bakar
+func expectError(t *testing.T, code string, message string) {
+	t.Helper()
+
+	result, _ := run(t, code)
+	err, ok := result.(*object.Error)
+	if !ok {
+		t.Fatalf("%s: hata bekleniyordu, bulunan %s", code, inspect(result))
+	}
+
+	if !strings.Contains(err.Text, message) {
+		t.Errorf("%s\nhata: %s\nistenen: %s", code, err.Text, message)
+	}
+}
+
+func inspect(o object.Object) string {
+	if o == nil {
+		return "nil"
+	}
+
+	return o.Inspect()
+}
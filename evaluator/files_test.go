@@ -0,0 +1,76 @@
+package evaluator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTemp(t *testing.T, name string, content string) (string, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "anka")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+
+	return file, func() { os.RemoveAll(dir) }
+}
+
+func TestReadLines(t *testing.T) {
+	file, cleanup := writeTemp(t, "satırlar.txt", "bir\niki\nüç\n")
+	defer cleanup()
+
+	expect(t, `
+s = []
+döngü i, satır in satır_oku("`+file+`") {
+    s.it(str(i) + satır)
+}
+s
+`, `["0bir", "1iki", "2üç"]`)
+
+	// döngüden erken çıkılınca dosya kapanır
+	expectError(t, `
+oku = satır_oku("`+file+`")
+döngü satır in oku {
+    dur
+}
+oku()
+`, "dosyası kapandı")
+
+	expectError(t, `
+oku = satır_oku("`+file+`")
+oku()
+oku()
+oku()
+oku()
+oku()
+`, "okunacak satır kalmadı")
+
+	long := strings.Repeat("a", 1024*1024)
+	file, cleanup = writeTemp(t, "uzun.txt", long+"\nb\n")
+	defer cleanup()
+
+	expect(t, `satır_oku("`+file+`")().uzunluk()`, "1048576")
+}
+
+func TestReadLinesTooLong(t *testing.T) {
+	file, cleanup := writeTemp(t, "çok_uzun.txt", strings.Repeat("a", maxLineLength+1))
+	defer cleanup()
+
+	expectError(t, `
+n = 0
+döngü satır in satır_oku("`+file+`") {
+    n += 1
+}
+n
+`, "okunamadı")
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math"
 	"math/big"
@@ -17,9 +18,9 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
-	"github.com/ankalang/anka/ast"
 	"github.com/ankalang/anka/lexer"
 	"github.com/ankalang/anka/object"
 	"github.com/ankalang/anka/parser"
@@ -27,395 +28,776 @@ import (
 	"github.com/ankalang/anka/util"
 )
 
-var scanner *bufio.Scanner
-var tok token.Token
-var scannerPosition int
-var requireCache map[string]object.Object
 
-func init() {
-	scanner = bufio.NewScanner(os.Stdin)
-	requireCache = make(map[string]object.Object)
+// arity yerleşik fonksiyonun argüman sayısıdır; max -1 ise sınır yoktur
+func arity(min, max int) *object.Arity {
+	return &object.Arity{Min: min, Max: max}
 }
 
-
 func getFns() map[string]*object.Builtin {
 	return map[string]*object.Builtin{
 		
 		"uzunluk": &object.Builtin{
-			Types: []string{object.STRING_OBJ, object.ARRAY_OBJ},
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ, object.ARRAY_OBJ, object.SET_OBJ, object.BYTES_OBJ},
 			Fn:    lenFn,
 		},
 		
 		"rast": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.NUMBER_OBJ},
 			Fn:    randFn,
 		},
 		
 		"çıkış": &object.Builtin{
+			Arity: arity(1, 2),
 			Types: []string{object.NUMBER_OBJ},
 			Fn:    exitFn,
 		},
 		
 		"bayrak": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.STRING_OBJ},
 			Fn:    flagFn,
 		},
 		
 		"pwd": &object.Builtin{
+			Arity: arity(0, 0),
 			Types: []string{},
 			Fn:    pwdFn,
 		},
 		
 		"cd": &object.Builtin{
+			Arity: arity(0, 1),
 			Types: []string{},
 			Fn:    cdFn,
 		},
 		
 		"eko": &object.Builtin{
+			Arity: arity(0, -1),
 			Types: []string{},
 			Fn:    echoFn,
 		},
 		
 		
 		"int": &object.Builtin{
-			Types: []string{object.STRING_OBJ, object.NUMBER_OBJ},
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ, object.NUMBER_OBJ, object.DECIMAL_OBJ},
 			Fn:    intFn,
 		},
 		
 		
 		"yuvarla": &object.Builtin{
-			Types: []string{object.STRING_OBJ, object.NUMBER_OBJ},
+			Arity: arity(1, 3),
+			Types: []string{object.STRING_OBJ, object.NUMBER_OBJ, object.DECIMAL_OBJ},
 			Fn:    roundFn,
 		},
 		
 		
 		"floor": &object.Builtin{
-			Types: []string{object.STRING_OBJ, object.NUMBER_OBJ},
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ, object.NUMBER_OBJ, object.DECIMAL_OBJ},
 			Fn:    floorFn,
 		},
 		
 		
 		"ceil": &object.Builtin{
-			Types: []string{object.STRING_OBJ, object.NUMBER_OBJ},
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ, object.NUMBER_OBJ, object.DECIMAL_OBJ},
 			Fn:    ceilFn,
 		},
 		
 		"num": &object.Builtin{
-			Types: []string{object.STRING_OBJ, object.NUMBER_OBJ},
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ, object.NUMBER_OBJ, object.DECIMAL_OBJ},
 			Fn:    numberFn,
 		},
 		
+		"ondalık": &object.Builtin{
+			Arity: arity(1, 3),
+			Types: []string{object.STRING_OBJ, object.NUMBER_OBJ, object.DECIMAL_OBJ},
+			Fn:    decimalFn,
+		},
+		
 		"sayımı": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.STRING_OBJ, object.NUMBER_OBJ},
 			Fn:    isNumberFn,
 		},
 		
 		"girdi": &object.Builtin{
+			Arity: arity(0, 0),
 			Next:  stdinNextFn,
 			Types: []string{},
 			Fn:    stdinFn,
 		},
 		
 		"env": &object.Builtin{
+			Arity: arity(1, 2),
 			Types: []string{},
 			Fn:    envFn,
 		},
 		
 		"arg": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.NUMBER_OBJ},
 			Fn:    argFn,
 		},
 		
 		"args": &object.Builtin{
+			Arity: arity(0, 0),
 			Types: []string{object.STRING_OBJ},
 			Fn:    argsFn,
 		},
 		
 		"tip": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{},
 			Fn:    typeFn,
 		},
 		
 		"ara": &object.Builtin{
+			Arity: arity(2, 2),
 			Types: []string{object.FUNCTION_OBJ, object.BUILTIN_OBJ},
 			Fn:    callFn,
 		},
 		
 		"chunk": &object.Builtin{
+			Arity: arity(2, 2),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    chunkFn,
 		},
 		
 		"ayır": &object.Builtin{
+			Arity: arity(1, 2),
 			Types: []string{object.STRING_OBJ},
 			Fn:    splitFn,
 		},
 		
 		"satırlar": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.STRING_OBJ},
 			Fn:    linesFn,
 		},
 		
 		
 		"json": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.STRING_OBJ},
 			Fn:    jsonFn,
 		},
 		
+		"jsonla": &object.Builtin{
+			Arity: arity(1, 2),
+			Types: []string{},
+			Fn:    jsonEncodeFn,
+		},
+		
 		"fmt": &object.Builtin{
+			Arity: arity(1, -1),
 			Types: []string{object.STRING_OBJ},
 			Fn:    fmtFn,
 		},
 		
 		"toplam": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    sumFn,
 		},
 		
 		"max": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    maxFn,
 		},
 		
 		"min": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    minFn,
 		},
 		
 		"azalt": &object.Builtin{
+			Arity: arity(3, 3),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    reduceFn,
 		},
 		
 		"sırala": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    sortFn,
 		},
 		
 		"kes": &object.Builtin{
-			Types: []string{object.ARRAY_OBJ},
+			Arity: arity(2, 2),
+			Types: []string{object.ARRAY_OBJ, object.SET_OBJ},
 			Fn:    intersectFn,
 		},
 		
 		"fark": &object.Builtin{
-			Types: []string{object.ARRAY_OBJ},
+			Arity: arity(2, 2),
+			Types: []string{object.ARRAY_OBJ, object.SET_OBJ},
 			Fn:    diffFn,
 		},
 		
 		"birleştir": &object.Builtin{
-			Types: []string{object.ARRAY_OBJ},
+			Arity: arity(2, 2),
+			Types: []string{object.ARRAY_OBJ, object.SET_OBJ},
 			Fn:    unionFn,
 		},
 		
 		"s_fark": &object.Builtin{
-			Types: []string{object.ARRAY_OBJ},
+			Arity: arity(2, 2),
+			Types: []string{object.ARRAY_OBJ, object.SET_OBJ},
 			Fn:    diffSymmetricFn,
 		},
 		
 		"düzleştir": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    flattenFn,
 		},
 		
 		"d_düzleştir": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    flattenDeepFn,
 		},
 		
 		"böl": &object.Builtin{
+			Arity: arity(2, 2),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    partitionFn,
 		},
 		
 		"haritala": &object.Builtin{
+			Arity: arity(2, 2),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    mapFn,
 		},
 		
 		"bazısında": &object.Builtin{
+			Arity: arity(2, 2),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    someFn,
 		},
 		
 		"hepsinde": &object.Builtin{
+			Arity: arity(2, 2),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    everyFn,
 		},
 		
 		"bul": &object.Builtin{
+			Arity: arity(2, 2),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    findFn,
 		},
 		
 		"filtre": &object.Builtin{
+			Arity: arity(2, 2),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    filterFn,
 		},
 		
 		"eşsiz": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    uniqueFn,
 		},
 		
+		"küme": &object.Builtin{
+			Arity: arity(0, 1),
+			Types: []string{object.ARRAY_OBJ, object.SET_OBJ},
+			Fn:    setFn,
+		},
+		
 		"str": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{},
 			Fn:    strFn,
 		},
 		
 		"herhangi": &object.Builtin{
+			Arity: arity(2, 2),
 			Types: []string{object.STRING_OBJ},
 			Fn:    anyFn,
 		},
 		
 		"arasında": &object.Builtin{
+			Arity: arity(3, 3),
 			Types: []string{object.NUMBER_OBJ},
 			Fn:    betweenFn,
 		},
 		
 		"önek": &object.Builtin{
+			Arity: arity(2, 2),
 			Types: []string{object.STRING_OBJ},
 			Fn:    prefixFn,
 		},
 		
 		"sonek": &object.Builtin{
+			Arity: arity(2, 2),
 			Types: []string{object.STRING_OBJ},
 			Fn:    suffixFn,
 		},
 		
 		"tekrarla": &object.Builtin{
+			Arity: arity(2, 2),
 			Types: []string{object.STRING_OBJ},
 			Fn:    repeatFn,
 		},
 		
 		"değiştir": &object.Builtin{
+			Arity: arity(3, 4),
 			Types: []string{object.STRING_OBJ},
 			Fn:    replaceFn,
 		},
 		
 		"başlık": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.STRING_OBJ},
 			Fn:    titleFn,
 		},
 		
 		"küçük": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.STRING_OBJ},
 			Fn:    lowerFn,
 		},
 		
 		"büyük": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.STRING_OBJ},
 			Fn:    upperFn,
 		},
 		
 		"bekleyerek": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.STRING_OBJ},
 			Fn:    waitFn,
 		},
 		"öldür": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.STRING_OBJ},
 			Fn:    killFn,
 		},
 		
 		"kırp": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.STRING_OBJ},
 			Fn:    trimFn,
 		},
 		
 		"göre_kırp": &object.Builtin{
+			Arity: arity(2, 2),
 			Types: []string{object.STRING_OBJ},
 			Fn:    trimByFn,
 		},
 		
 		"dizin": &object.Builtin{
+			Arity: arity(2, 2),
 			Types: []string{object.STRING_OBJ},
 			Fn:    indexFn,
 		},
 		
 		"son_dizin": &object.Builtin{
+			Arity: arity(2, 2),
 			Types: []string{object.STRING_OBJ},
 			Fn:    lastIndexFn,
 		},
 		
 		"shift": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    shiftFn,
 		},
 		
 		"tersine": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.ARRAY_OBJ, object.STRING_OBJ},
 			Fn:    reverseFn,
 		},
 		
 		"karıştır": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    shuffleFn,
 		},
 		
 		"it": &object.Builtin{
-			Types: []string{object.ARRAY_OBJ},
+			Arity: arity(2, 2),
+			Types: []string{object.ARRAY_OBJ, object.SET_OBJ},
 			Fn:    pushFn,
 		},
 		
 		"çıkar": &object.Builtin{
-			Types: []string{object.ARRAY_OBJ, object.HASH_OBJ},
+			Arity: arity(1, 2),
+			Types: []string{object.ARRAY_OBJ, object.HASH_OBJ, object.SET_OBJ},
 			Fn:    popFn,
 		},
 		
 		
 		"anahtarlar": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.ARRAY_OBJ, object.HASH_OBJ},
 			Fn:    keysFn,
 		},
 		
 		"değerler": &object.Builtin{
-			Types: []string{object.HASH_OBJ},
+			Arity: arity(1, 1),
+			Types: []string{object.HASH_OBJ, object.SET_OBJ},
 			Fn:    valuesFn,
 		},
 		
 		"eşyalar": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.HASH_OBJ},
 			Fn:    itemsFn,
 		},
 		
 		"kat": &object.Builtin{
+			Arity: arity(1, 2),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    joinFn,
 		},
 		
 		"uyu": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.NUMBER_OBJ},
 			Fn:    sleepFn,
 		},
 		
 		"kaynak": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.STRING_OBJ},
 			Fn:    sourceFn,
 		},
 		
 		"src": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.STRING_OBJ},
 			Fn:    requireFn,
 		},
 		
 		"uygula": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.STRING_OBJ},
 			Fn:    execFn,
 		},
 		
 		"eval": &object.Builtin{
+			Arity: arity(1, 1),
 			Types: []string{object.STRING_OBJ},
 			Fn:    evalFn,
 		},
 		
 		"tsv": &object.Builtin{
+			Arity: arity(1, 3),
 			Types: []string{object.ARRAY_OBJ},
 			Fn:    tsvFn,
 		},
 		
 		"unix_ms": &object.Builtin{
+			Arity: arity(0, 0),
 			Types: []string{},
 			Fn:    unixMsFn,
 		},
+		
+		"hata": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ, object.HASH_OBJ},
+			Fn:    errorFn,
+		},
+		
+		"oku": &object.Builtin{
+			Arity: arity(1, 2),
+			Types: []string{object.STRING_OBJ},
+			Fn:    readFn,
+		},
+		
+		"bayt": &object.Builtin{
+			Arity: arity(1, 2),
+			Types: []string{object.STRING_OBJ, object.ARRAY_OBJ, object.BYTES_OBJ},
+			Fn:    bytesFn,
+		},
+		
+		"metin": &object.Builtin{
+			Arity: arity(1, 2),
+			Types: []string{object.BYTES_OBJ},
+			Fn:    bytesToStringFn,
+		},
+		
+		"base64_kodla": &object.Builtin{
+			Arity: arity(1, 2),
+			Types: []string{object.STRING_OBJ, object.BYTES_OBJ},
+			Fn:    base64EncodeFn,
+		},
+		
+		"base64_çöz": &object.Builtin{
+			Arity: arity(1, 2),
+			Types: []string{object.STRING_OBJ},
+			Fn:    base64DecodeFn,
+		},
+		
+		"hex_kodla": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ, object.BYTES_OBJ},
+			Fn:    hexEncodeFn,
+		},
+		
+		"hex_çöz": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ},
+			Fn:    hexDecodeFn,
+		},
+		
+		"md5": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ, object.BYTES_OBJ},
+			Fn:    digestFn("md5"),
+		},
+		
+		"sha1": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ, object.BYTES_OBJ},
+			Fn:    digestFn("sha1"),
+		},
+		
+		"sha256": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ, object.BYTES_OBJ},
+			Fn:    digestFn("sha256"),
+		},
+		
+		"sha512": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ, object.BYTES_OBJ},
+			Fn:    digestFn("sha512"),
+		},
+		
+		"hmac": &object.Builtin{
+			Arity: arity(3, 3),
+			Types: []string{},
+			Fn:    hmacFn,
+		},
+		
+		"güvenli_eşit": &object.Builtin{
+			Arity: arity(2, 2),
+			Types: []string{object.STRING_OBJ, object.BYTES_OBJ},
+			Fn:    constantTimeEqualFn,
+		},
+		
+		"satır_oku": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ},
+			Fn:    readLinesFn,
+		},
+		
+		"listele": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ},
+			Fn:    globFn,
+		},
+		
+		"var_mı": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ},
+			Fn:    existsFn,
+		},
+		
+		"bilgi": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ},
+			Fn:    statFn,
+		},
+		
+		"klasör_oluştur": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ},
+			Fn:    mkdirFn,
+		},
+		
+		"sil": &object.Builtin{
+			Arity: arity(1, 2),
+			Types: []string{object.STRING_OBJ},
+			Fn:    removeFn,
+		},
+		
+		"taşı": &object.Builtin{
+			Arity: arity(2, 2),
+			Types: []string{object.STRING_OBJ},
+			Fn:    renameFn,
+		},
+		
+		"kopyala": &object.Builtin{
+			Arity: arity(2, 2),
+			Types: []string{object.STRING_OBJ},
+			Fn:    copyFn,
+		},
+		
+		"http_istek": &object.Builtin{
+			Arity: arity(2, 3),
+			Types: []string{object.STRING_OBJ},
+			Fn:    httpRequestFn,
+		},
+		
+		"http_sunucu": &object.Builtin{
+			Arity: arity(2, 2),
+			Types: []string{object.STRING_OBJ},
+			Fn:    httpServeFn,
+		},
+		
+		"eşleşir": &object.Builtin{
+			Arity: arity(2, 2),
+			Types: []string{object.STRING_OBJ},
+			Fn:    regexTestFn,
+		},
+		
+		"eşleşme": &object.Builtin{
+			Arity: arity(2, 2),
+			Types: []string{object.STRING_OBJ},
+			Fn:    regexMatchFn,
+		},
+		
+		"eşleşmeler": &object.Builtin{
+			Arity: arity(2, 3),
+			Types: []string{object.STRING_OBJ},
+			Fn:    regexMatchAllFn,
+		},
+		
+		"desen_değiştir": &object.Builtin{
+			Arity: arity(3, 3),
+			Types: []string{object.STRING_OBJ},
+			Fn:    regexReplaceFn,
+		},
+		
+		"desen_ayır": &object.Builtin{
+			Arity: arity(2, 3),
+			Types: []string{object.STRING_OBJ},
+			Fn:    regexSplitFn,
+		},
+		
+		"şimdi": &object.Builtin{
+			Arity: arity(0, 0),
+			Types: []string{},
+			Fn:    nowFn,
+		},
+		
+		"tarih": &object.Builtin{
+			Arity: arity(1, 6),
+			Types: []string{object.STRING_OBJ, object.NUMBER_OBJ},
+			Fn:    dateFn,
+		},
+		
+		"biçimle": &object.Builtin{
+			Arity: arity(2, 3),
+			Types: []string{object.TIME_OBJ},
+			Fn:    formatTimeFn,
+		},
+		
+		"bölgede": &object.Builtin{
+			Arity: arity(2, 2),
+			Types: []string{object.TIME_OBJ},
+			Fn:    inZoneFn,
+		},
+		
+		"ekle": &object.Builtin{
+			Arity: arity(2, 2),
+			Types: []string{object.TIME_OBJ},
+			Fn:    addDurationFn,
+		},
+		
+		"gün_ekle": &object.Builtin{
+			Arity: arity(2, 2),
+			Types: []string{object.TIME_OBJ},
+			Fn:    addDateFn("gün_ekle", 0, 0, 1),
+		},
+		
+		"ay_ekle": &object.Builtin{
+			Arity: arity(2, 2),
+			Types: []string{object.TIME_OBJ},
+			Fn:    addDateFn("ay_ekle", 0, 1, 0),
+		},
+		
+		"yıl_ekle": &object.Builtin{
+			Arity: arity(2, 2),
+			Types: []string{object.TIME_OBJ},
+			Fn:    addDateFn("yıl_ekle", 1, 0, 0),
+		},
+		
+		"süre": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.STRING_OBJ},
+			Fn:    durationFn,
+		},
+		
+		"eşzamanlı": &object.Builtin{
+			Arity: arity(1, -1),
+			Types: []string{object.FUNCTION_OBJ, object.BUILTIN_OBJ},
+			Fn:    spawnFn,
+		},
+		
+		"bekle": &object.Builtin{
+			Arity: arity(1, 2),
+			Types: []string{object.TASK_OBJ, object.WAITGROUP_OBJ},
+			Fn:    awaitFn,
+		},
+		
+		"kanal": &object.Builtin{
+			Arity: arity(0, 1),
+			Types: []string{},
+			Fn:    channelFn,
+		},
+		
+		"gönder": &object.Builtin{
+			Arity: arity(2, 2),
+			Types: []string{object.CHANNEL_OBJ},
+			Fn:    sendFn,
+		},
+		
+		"al": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.CHANNEL_OBJ},
+			Fn:    receiveFn,
+		},
+		
+		"kapat": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.CHANNEL_OBJ},
+			Fn:    closeFn,
+		},
+		
+		"seç": &object.Builtin{
+			Arity: arity(1, 2),
+			Types: []string{object.ARRAY_OBJ},
+			Fn:    selectFn,
+		},
+		
+		"bekleme_grubu": &object.Builtin{
+			Arity: arity(0, 0),
+			Types: []string{},
+			Fn:    waitGroupFn,
+		},
+		
+		"artır": &object.Builtin{
+			Arity: arity(1, 2),
+			Types: []string{object.WAITGROUP_OBJ},
+			Fn:    waitGroupAddFn,
+		},
+		
+		"tamamla": &object.Builtin{
+			Arity: arity(1, 1),
+			Types: []string{object.WAITGROUP_OBJ},
+			Fn:    waitGroupDoneFn,
+		},
+		
+		"paralel_haritala": &object.Builtin{
+			Arity: arity(2, 3),
+			Types: []string{object.ARRAY_OBJ},
+			Fn:    parallelMapFn,
+		},
 	}
 }
 
@@ -473,7 +855,7 @@ func validateVarArgs(tok token.Token, name string, args []object.Object, specs [
 		
 		match := true
 		for i, types := range spec {
-			if i < len(args) && !util.Contains(types, string(args[i].Type())) {
+			if i < len(args) && !util.Contains(types, string(args[i].Type())) && !util.Contains(types, object.ANY_OBJ) {
 				match = false
 				break
 			}
@@ -507,16 +889,20 @@ func usageVarArgs(name string, specs [][][]string) string {
 
 
 func lenFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
-	err := validateArgs(tok, "len", args, 1, [][]string{{object.STRING_OBJ, object.ARRAY_OBJ}})
+	err := validateArgs(tok, "len", args, 1, [][]string{{object.STRING_OBJ, object.ARRAY_OBJ, object.SET_OBJ, object.BYTES_OBJ}})
 	if err != nil {
 		return err
 	}
 
 	switch arg := args[0].(type) {
+	case *object.Set:
+		return object.NewInteger(tok, int64(len(arg.Elements)))
+	case *object.Bytes:
+		return object.NewInteger(tok, int64(len(arg.Value)))
 	case *object.Array:
-		return &object.Number{Token: tok, Value: float64(len(arg.Elements))}
+		return object.NewInteger(tok, int64(len(arg.Elements)))
 	case *object.String:
-		return &object.Number{Token: tok, Value: float64(len(arg.Value))}
+		return object.NewInteger(tok, int64(len(arg.Value)))
 	default:
 		return newError(tok, "len içim argüman yok, bulunan %s", args[0].Type())
 	}
@@ -536,7 +922,7 @@ func randFn(tok token.Token, env *object.Environment, args ...object.Object) obj
 		return newError(tok, "'rast(%v)' çağırırken hata oluştu: %s", arg.Value, e.Error())
 	}
 
-	return &object.Number{Token: tok, Value: float64(r.Int64())}
+	return object.NewInteger(tok, r.Int64())
 }
 
 
@@ -567,7 +953,33 @@ func exitFn(tok token.Token, env *object.Environment, args ...object.Object) obj
 
 
 func unixMsFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
-	return &object.Number{Value: float64(time.Now().UnixNano() / 1000000)}
+	return object.NewInteger(tok, time.Now().UnixNano()/1000000)
+}
+
+
+func errorFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "hata", args, [][][]string{
+		{{object.STRING_OBJ}},
+		{{object.HASH_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if spec == 1 {
+		pair, ok := args[0].(*object.Hash).GetPair("mesaj")
+
+		if !ok {
+			return newError(tok, "hata(...) için verilen haritada \"mesaj\" anahtarı bulunmalı")
+		}
+
+		e := newError(tok, "%s", pair.Value.Inspect())
+		e.Data = args[0].(*object.Hash)
+		return e
+	}
+
+	return newError(tok, "%s", args[0].(*object.String).Value)
 }
 
 
@@ -695,13 +1107,13 @@ func echoFn(tok token.Token, env *object.Environment, args ...object.Object) obj
 
 
 func intFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
-	err := validateArgs(tok, "int", args, 1, [][]string{{object.NUMBER_OBJ, object.STRING_OBJ}})
+	err := validateArgs(tok, "int", args, 1, [][]string{{object.NUMBER_OBJ, object.STRING_OBJ, object.DECIMAL_OBJ}})
 	if err != nil {
 		return err
 	}
 
-	return applyMathFunction(tok, args[0], func(n float64) float64 {
-		return float64(int64(n))
+	return applyIntegerFunction(tok, args[0], func(n float64) float64 {
+		return n
 	}, "int")
 }
 
@@ -709,11 +1121,26 @@ func intFn(tok token.Token, env *object.Environment, args ...object.Object) obje
 
 func roundFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
 	
-	err := validateArgs(tok, "round", args[:1], 1, [][]string{{object.NUMBER_OBJ, object.STRING_OBJ}})
+	err := validateArgs(tok, "round", args[:1], 1, [][]string{{object.NUMBER_OBJ, object.STRING_OBJ, object.DECIMAL_OBJ}})
 	if err != nil {
 		return err
 	}
 
+	
+	if d, ok := args[0].(*object.Decimal); ok {
+		err, _ := validateVarArgs(tok, "round", args[1:], [][][]string{
+			{{object.NUMBER_OBJ}, {object.STRING_OBJ}},
+			{{object.NUMBER_OBJ}},
+			{},
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return roundDecimal(tok, d, args[1:])
+	}
+
 	decimal := float64(1)
 
 	
@@ -726,6 +1153,10 @@ func roundFn(tok token.Token, env *object.Environment, args ...object.Object) ob
 		decimal = float64(math.Pow(10, args[1].(*object.Number).Value))
 	}
 
+	if n, ok := args[0].(*object.Number); ok && n.Integer && decimal >= 1 {
+		return n
+	}
+
 	return applyMathFunction(tok, args[0], func(n float64) float64 {
 		return math.Round(n*decimal) / decimal
 	}, "round")
@@ -734,23 +1165,23 @@ func roundFn(tok token.Token, env *object.Environment, args ...object.Object) ob
 
 
 func floorFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
-	err := validateArgs(tok, "floor", args, 1, [][]string{{object.NUMBER_OBJ, object.STRING_OBJ}})
+	err := validateArgs(tok, "floor", args, 1, [][]string{{object.NUMBER_OBJ, object.STRING_OBJ, object.DECIMAL_OBJ}})
 	if err != nil {
 		return err
 	}
 
-	return applyMathFunction(tok, args[0], math.Floor, "floor")
+	return applyIntegerFunction(tok, args[0], math.Floor, "floor")
 }
 
 
 
 func ceilFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
-	err := validateArgs(tok, "ceil", args, 1, [][]string{{object.NUMBER_OBJ, object.STRING_OBJ}})
+	err := validateArgs(tok, "ceil", args, 1, [][]string{{object.NUMBER_OBJ, object.STRING_OBJ, object.DECIMAL_OBJ}})
 	if err != nil {
 		return err
 	}
 
-	return applyMathFunction(tok, args[0], math.Ceil, "ceil")
+	return applyIntegerFunction(tok, args[0], math.Ceil, "ceil")
 }
 
 
@@ -759,6 +1190,31 @@ func ceilFn(tok token.Token, env *object.Environment, args ...object.Object) obj
 
 
 
+// applyIntegerFunction sonucu tamsayı olan yuvarlamalar içindir; tamsayılar
+// float64'e dönüştürülmeden olduğu gibi döner
+func applyIntegerFunction(tok token.Token, arg object.Object, fn func(float64) float64, fname string) object.Object {
+	if s, ok := arg.(*object.String); ok {
+		n, ok := parseNumber(tok, s.Value)
+		if !ok {
+			return newError(tok, "%s(...) sadece yazılarla kullanılabilir, '%s' değil", fname, s.Value)
+		}
+
+		arg = n
+	}
+
+	if d, ok := arg.(*object.Decimal); ok {
+		mode := map[string]string{"int": "aşağı", "floor": "taban", "ceil": "tavan"}[fname]
+		return object.NewBigInteger(tok, rescaleDecimal(tok, d, 0, mode).Unscaled)
+	}
+
+	n := arg.(*object.Number)
+	if n.Integer {
+		return object.NewBigInteger(tok, n.BigInt())
+	}
+
+	return truncateToInteger(tok, fn(n.Value))
+}
+
 func applyMathFunction(tok token.Token, arg object.Object, fn func(float64) float64, fname string) object.Object {
 	switch arg := arg.(type) {
 	case *object.Number:
@@ -780,7 +1236,7 @@ func applyMathFunction(tok token.Token, arg object.Object, fn func(float64) floa
 
 
 func numberFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
-	err := validateArgs(tok, "number", args, 1, [][]string{{object.NUMBER_OBJ, object.STRING_OBJ}})
+	err := validateArgs(tok, "number", args, 1, [][]string{{object.NUMBER_OBJ, object.STRING_OBJ, object.DECIMAL_OBJ}})
 	if err != nil {
 		return err
 	}
@@ -788,14 +1244,17 @@ func numberFn(tok token.Token, env *object.Environment, args ...object.Object) o
 	switch arg := args[0].(type) {
 	case *object.Number:
 		return arg
+	case *object.Decimal:
+		f, _ := arg.Rat().Float64()
+		return &object.Number{Token: tok, Value: f}
 	case *object.String:
-		i, err := strconv.ParseFloat(arg.Value, 64)
+		n, ok := parseNumber(tok, arg.Value)
 
-		if err != nil {
+		if !ok {
 			return newError(tok, "number(...) sadece rakam içeren yazılar ile kullanılabilir, '%s' ile değil", arg.Value)
 		}
 
-		return &object.Number{Token: tok, Value: i}
+		return n
 	default:
 		
 		return newError(tok, "garip bir hata oluştu. Kod: 47586665sx7", args[0].Type())
@@ -822,25 +1281,33 @@ func isNumberFn(tok token.Token, env *object.Environment, args ...object.Object)
 
 
 func stdinFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
-	v := scanner.Scan()
+	in := interpreterOf(env)
+	in.scannerLock.Lock()
+	defer in.scannerLock.Unlock()
+
+	v := in.scanner.Scan()
 
 	if !v {
 		return EOF
 	}
 
-	return &object.String{Token: tok, Value: scanner.Text()}
+	return &object.String{Token: tok, Value: in.scanner.Text()}
 }
-func stdinNextFn() (object.Object, object.Object) {
-	v := scanner.Scan()
+func stdinNextFn(env *object.Environment) (object.Object, object.Object) {
+	in := interpreterOf(env)
+	in.scannerLock.Lock()
+	defer in.scannerLock.Unlock()
+
+	v := in.scanner.Scan()
 
 	if !v {
 		return nil, EOF
 	}
 
 	defer func() {
-		scannerPosition += 1
+		in.scannerPosition += 1
 	}()
-	return &object.Number{Value: float64(scannerPosition)}, &object.String{Token: tok, Value: scanner.Text()}
+	return object.NewInteger(token.Token{}, int64(in.scannerPosition)), &object.String{Value: in.scanner.Text()}
 }
 
 
@@ -900,6 +1367,10 @@ func typeFn(tok token.Token, env *object.Environment, args ...object.Object) obj
 		return err
 	}
 
+	if instance, ok := args[0].(*object.Instance); ok {
+		return &object.String{Token: tok, Value: instance.Of.Name}
+	}
+
 	return &object.String{Token: tok, Value: string(args[0].Type())}
 }
 
@@ -996,70 +1467,42 @@ func linesFn(tok token.Token, env *object.Environment, args ...object.Object) ob
 
 
 func jsonFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
-	
-	
-	
-	
-	
-	
-	
-	
-	
-	
-
 	err := validateArgs(tok, "json", args, 1, [][]string{{object.STRING_OBJ}})
 	if err != nil {
 		return err
 	}
 
-	s := args[0].(*object.String)
-	str := strings.TrimSpace(s.Value)
-	env = object.NewEnvironment(env.Writer, env.Dir, env.Version)
-	l := lexer.New(str)
-	p := parser.New(l)
-	var node ast.Node
-	ok := false
+	return decodeJSON(tok, args[0].(*object.String).Value)
+}
 
-	
-	
-	
-	
-	
-	
-	
-	if len(str) != 0 {
-		switch str[0] {
-		case '{':
-			node, ok = p.ParseHashLiteral().(*ast.HashLiteral)
-		case '[':
-			node, ok = p.ParseArrayLiteral().(*ast.ArrayLiteral)
-		}
-	}
 
-	
-	
-	if len(str) == 0 || (str[0] == '"' && str[len(str)-1] == '"') {
-		node, ok = p.ParseStringLiteral().(*ast.StringLiteral)
-	}
 
-	if util.IsNumber(str) {
-		node, ok = p.ParseNumberLiteral().(*ast.NumberLiteral)
-	}
+func jsonEncodeFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "jsonla", args, [][][]string{
+		{{object.ANY_OBJ}},
+		{{object.ANY_OBJ}, {object.NUMBER_OBJ}},
+		{{object.ANY_OBJ}, {object.STRING_OBJ}},
+	})
 
-	if str == "false" || str == "true" {
-		node, ok = p.ParseBoolean().(*ast.Boolean)
+	if err != nil {
+		return err
 	}
 
-	if str == "null" {
-		return NULL
-	}
+	indent := ""
 
-	if ok {
-		return Eval(node, env)
-	}
+	switch spec {
+	case 1:
+		number := args[1].(*object.Number)
+		if !number.IsInt() || number.Value < 0 {
+			return newError(tok, "jsonla(...) girintisi negatif olmayan bir tamsayı olmalı, bulunan %s", number.Inspect())
+		}
 
-	return newError(tok, "`json`'a gelen argüman JSON objesi olmalı, bulunan '%s'", s.Value)
+		indent = strings.Repeat(" ", number.Int())
+	case 2:
+		indent = args[1].(*object.String).Value
+	}
 
+	return encodeJSON(tok, args[0], indent)
 }
 
 
@@ -1082,25 +1525,28 @@ func sumFn(tok token.Token, env *object.Environment, args ...object.Object) obje
 
 	arr := args[0].(*object.Array)
 	if arr.Empty() {
-		return &object.Number{Token: tok, Value: float64(0)}
+		return object.NewInteger(tok, 0)
 	}
 
 	if !arr.Homogeneous() {
 		return newError(tok, "ekle(...) sadece homojenik listelerde kullanılabilir, bulunan: %s", arr.Inspect())
 	}
 
-	if arr.Elements[0].Type() != object.NUMBER_OBJ {
+	if arr.Elements[0].Type() != object.NUMBER_OBJ && arr.Elements[0].Type() != object.DECIMAL_OBJ {
 		return newError(tok, "ekle(...) sadece sayılardan oluşan listelerde kullanılabilir, bulunan: %s", arr.Inspect())
 	}
 
-	var sum float64 = 0
+	var sum object.Object = object.NewInteger(tok, 0)
 
 	for _, v := range arr.Elements {
-		elem := v.(*object.Number)
-		sum += elem.Value
+		if v.Type() == object.DECIMAL_OBJ {
+			sum = evalDecimalInfixExpression(tok, "+", sum, v)
+		} else {
+			sum = evalNumberInfixExpression(tok, "+", sum, v)
+		}
 	}
 
-	return &object.Number{Token: tok, Value: sum}
+	return sum
 }
 
 
@@ -1123,17 +1569,17 @@ func maxFn(tok token.Token, env *object.Environment, args ...object.Object) obje
 		return newError(tok, "max(...) sadece sayılardan oluşan listelerde kullanılabilir, bulunan: %s", arr.Inspect())
 	}
 
-	max := arr.Elements[0].(*object.Number).Value
+	max := arr.Elements[0].(*object.Number)
 
 	for _, v := range arr.Elements[1:] {
 		elem := v.(*object.Number)
 
-		if elem.Value > max {
-			max = elem.Value
+		if compareNumbers(elem, max) > 0 {
+			max = elem
 		}
 	}
 
-	return &object.Number{Token: tok, Value: max}
+	return max
 }
 
 
@@ -1156,17 +1602,17 @@ func minFn(tok token.Token, env *object.Environment, args ...object.Object) obje
 		return newError(tok, "min(...) sadece sayılardan oluşan listelerde kullanılabilir, bulunan: %s", arr.Inspect())
 	}
 
-	min := arr.Elements[0].(*object.Number).Value
+	min := arr.Elements[0].(*object.Number)
 
 	for _, v := range arr.Elements[1:] {
 		elem := v.(*object.Number)
 
-		if elem.Value < min {
-			min = elem.Value
+		if compareNumbers(elem, min) < 0 {
+			min = elem
 		}
 	}
 
-	return &object.Number{Token: tok, Value: min}
+	return min
 }
 
 
@@ -1179,7 +1625,7 @@ func reduceFn(tok token.Token, env *object.Environment, args ...object.Object) o
 	accumulator := args[2]
 
 	for _, v := range args[0].(*object.Array).Elements {
-		accumulator = applyFunction(tok, args[1].(*object.Function), env, []object.Object{accumulator, v})
+		accumulator = applyFunction(tok, args[1], env, []object.Object{accumulator, v})
 	}
 
 	return accumulator
@@ -1205,17 +1651,13 @@ func sortFn(tok token.Token, env *object.Environment, args ...object.Object) obj
 
 	switch elements[0].(type) {
 	case *object.Number:
-		a := []float64{}
-		for _, v := range elements {
-			a = append(a, v.(*object.Number).Value)
-		}
-		sort.Float64s(a)
+		o := make([]object.Object, len(elements))
+		copy(o, elements)
 
-		o := []object.Object{}
+		sort.SliceStable(o, func(i, j int) bool {
+			return compareNumbers(o[i].(*object.Number), o[j].(*object.Number)) < 0
+		})
 
-		for _, v := range a {
-			o = append(o, &object.Number{Token: tok, Value: v})
-		}
 		return &object.Array{Elements: o}
 	case *object.String:
 		a := []string{}
@@ -1237,6 +1679,10 @@ func sortFn(tok token.Token, env *object.Environment, args ...object.Object) obj
 
 
 func intersectFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	if len(args) == 2 && args[0].Type() == object.SET_OBJ {
+		return setOperation(tok, "&", args[0].(*object.Set), args[1])
+	}
+
 	err := validateArgs(tok, "intersect", args, 2, [][]string{{object.ARRAY_OBJ}, {object.ARRAY_OBJ}})
 	if err != nil {
 		return err
@@ -1264,6 +1710,14 @@ func intersectFn(tok token.Token, env *object.Environment, args ...object.Object
 
 
 func diff(symmetric bool, fnName string, tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	if len(args) == 2 && args[0].Type() == object.SET_OBJ {
+		if symmetric {
+			return setOperation(tok, "^", args[0].(*object.Set), args[1])
+		}
+
+		return setOperation(tok, "-", args[0].(*object.Set), args[1])
+	}
+
 	err := validateArgs(tok, fnName, args, 2, [][]string{{object.ARRAY_OBJ}, {object.ARRAY_OBJ}})
 	if err != nil {
 		return err
@@ -1306,6 +1760,10 @@ func diffSymmetricFn(tok token.Token, env *object.Environment, args ...object.Ob
 
 
 func unionFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	if len(args) == 2 && args[0].Type() == object.SET_OBJ {
+		return setOperation(tok, "|", args[0].(*object.Set), args[1])
+	}
+
 	err := validateArgs(tok, "union", args, 2, [][]string{{object.ARRAY_OBJ}, {object.ARRAY_OBJ}})
 	if err != nil {
 		return err
@@ -1501,8 +1959,8 @@ func findFn(tok token.Token, env *object.Environment, args ...object.Object) obj
 			}
 
 			match := true
-			for k, pair := range predicate.Pairs {
-				toCompare, ok := v.GetPair(k.Value)
+			for _, pair := range predicate.OrderedPairs() {
+				toCompare, ok := v.GetPair(pair.Key.(object.Hashable).HashKey().Value)
 				if !ok {
 					match = false
 					continue
@@ -1784,7 +2242,7 @@ func indexFn(tok token.Token, env *object.Environment, args ...object.Object) ob
 		return NULL
 	}
 
-	return &object.Number{Token: tok, Value: float64(i)}
+	return object.NewInteger(tok, int64(i))
 }
 
 
@@ -1800,7 +2258,7 @@ func lastIndexFn(tok token.Token, env *object.Environment, args ...object.Object
 		return NULL
 	}
 
-	return &object.Number{Token: tok, Value: float64(i)}
+	return object.NewInteger(tok, int64(i))
 }
 
 
@@ -1838,14 +2296,11 @@ func shiftFn(tok token.Token, env *object.Environment, args ...object.Object) ob
 		return err
 	}
 
-	array := args[0].(*object.Array)
-	if len(array.Elements) == 0 {
-		return NULL
+	if e, ok := args[0].(*object.Array).Shift(); ok {
+		return e
 	}
-	e := array.Elements[0]
-	array.Elements = append(array.Elements[:0], array.Elements[1:]...)
 
-	return e
+	return NULL
 }
 
 
@@ -1904,14 +2359,22 @@ func shuffleFn(tok token.Token, env *object.Environment, args ...object.Object)
 
 
 func pushFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
-	err := validateArgs(tok, "push", args, 2, [][]string{{object.ARRAY_OBJ}, {object.NULL_OBJ,
-		object.ARRAY_OBJ, object.NUMBER_OBJ, object.STRING_OBJ, object.HASH_OBJ}})
+	if len(args) > 0 && args[0].Type() == object.SET_OBJ {
+		err := validateArgs(tok, "push", args, 2, [][]string{{object.SET_OBJ}, {object.ANY_OBJ}})
+		if err != nil {
+			return err
+		}
+
+		return addToSet(tok, args[0].(*object.Set), args[1:])
+	}
+
+	err := validateArgs(tok, "push", args, 2, [][]string{{object.ARRAY_OBJ}, {object.ANY_OBJ}})
 	if err != nil {
 		return err
 	}
 
 	array := args[0].(*object.Array)
-	array.Elements = append(array.Elements, args[1])
+	array.Push(args[1])
 
 	return array
 }
@@ -1926,6 +2389,8 @@ func popFn(tok token.Token, env *object.Environment, args ...object.Object) obje
 			err = validateArgs(tok, "pop", args, 1, [][]string{{object.ARRAY_OBJ}})
 		} else if args[0].Type() == object.HASH_OBJ {
 			err = validateArgs(tok, "pop", args, 2, [][]string{{object.HASH_OBJ}})
+		} else if args[0].Type() == object.SET_OBJ {
+			err = validateArgs(tok, "pop", args, 2, [][]string{{object.SET_OBJ}, {object.ANY_OBJ}})
 		}
 	}
 	if err != nil {
@@ -1936,23 +2401,23 @@ func popFn(tok token.Token, env *object.Environment, args ...object.Object) obje
 	}
 	switch arg := args[0].(type) {
 	case *object.Array:
-		if len(arg.Elements) > 0 {
-			elem := arg.Elements[len(arg.Elements)-1]
-			arg.Elements = arg.Elements[0 : len(arg.Elements)-1]
+		if elem, ok := arg.Pop(); ok {
 			return elem
 		}
 	case *object.Hash:
 		if len(args) == 2 {
 			key := args[1].(object.Hashable)
 			hashKey := key.HashKey()
-			item, ok := arg.Pairs[hashKey]
-			if ok {
-				pairs := make(map[object.HashKey]object.HashPair)
-				pairs[hashKey] = item
-				delete(arg.Pairs, hashKey)
-				return &object.Hash{Pairs: pairs}
+			if item, ok := arg.Delete(hashKey); ok {
+				popped := &object.Hash{}
+				popped.Set(item.Key, item.Value)
+				return popped
 			}
 		}
+	case *object.Set:
+		if len(args) == 2 && arg.Remove(args[1]) {
+			return args[1]
+		}
 	}
 	return NULL
 }
@@ -1969,13 +2434,12 @@ func keysFn(tok token.Token, env *object.Environment, args ...object.Object) obj
 		length := len(arg.Elements)
 		newElements := make([]object.Object, length, length)
 		for k := range arg.Elements {
-			newElements[k] = &object.Number{Token: tok, Value: float64(k)}
+			newElements[k] = object.NewInteger(tok, int64(k))
 		}
 		return &object.Array{Elements: newElements}
 	case *object.Hash:
-		pairs := arg.Pairs
 		keys := []object.Object{}
-		for _, pair := range pairs {
+		for _, pair := range arg.OrderedPairs() {
 			key := pair.Key
 			keys = append(keys, key)
 		}
@@ -1986,14 +2450,17 @@ func keysFn(tok token.Token, env *object.Environment, args ...object.Object) obj
 
 
 func valuesFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
-	err := validateArgs(tok, "values", args, 1, [][]string{{object.HASH_OBJ}})
+	err := validateArgs(tok, "values", args, 1, [][]string{{object.HASH_OBJ, object.SET_OBJ}})
 	if err != nil {
 		return err
 	}
+
+	if set, ok := args[0].(*object.Set); ok {
+		return &object.Array{Token: tok, Elements: set.Ordered()}
+	}
 	hash := args[0].(*object.Hash)
-	pairs := hash.Pairs
 	values := []object.Object{}
-	for _, pair := range pairs {
+	for _, pair := range hash.OrderedPairs() {
 		value := pair.Value
 		values = append(values, value)
 	}
@@ -2007,9 +2474,8 @@ func itemsFn(tok token.Token, env *object.Environment, args ...object.Object) ob
 		return err
 	}
 	hash := args[0].(*object.Hash)
-	pairs := hash.Pairs
 	items := []object.Object{}
-	for _, pair := range pairs {
+	for _, pair := range hash.OrderedPairs() {
 		key := pair.Key
 		value := pair.Value
 		item := &object.Array{Elements: []object.Object{key, value}}
@@ -2059,22 +2525,16 @@ func sleepFn(tok token.Token, env *object.Environment, args ...object.Object) ob
 
 const ANK_SOURCE_DEPTH = "10"
 
-var sourceDepth, _ = strconv.Atoi(ANK_SOURCE_DEPTH)
-var sourceLevel = 0
-
 func sourceFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
 	file, _ := util.ExpandPath(args[0].Inspect())
 	return doSource(tok, env, file, args...)
 }
 
-
-var history = make(map[string]string)
-
-var packageAliases map[string]string
-var packageAliasesLoaded bool
-
 func requireFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
-	if !packageAliasesLoaded {
+	in := interpreterOf(env)
+	in.requireLock.Lock()
+
+	if !in.packageAliasesLoaded {
 		a, err := ioutil.ReadFile("./paketler.json")
 
 		
@@ -2083,24 +2543,35 @@ func requireFn(tok token.Token, env *object.Environment, args ...object.Object)
 			
 			
 			
-			json.Unmarshal(a, &packageAliases)
+			json.Unmarshal(a, &in.packageAliases)
 		}
 
-		packageAliasesLoaded = true
+		in.packageAliasesLoaded = true
 	}
 
-	file := util.UnaliasPath(args[0].Inspect(), packageAliases)
+	file := util.UnaliasPath(args[0].Inspect(), in.packageAliases)
 
 	if !strings.HasPrefix(file, "@") {
 		file = filepath.Join(env.Dir, file)
 	}
 
-	if evaluated, ok := requireCache[file]; ok {
+	evaluated, ok := in.requireCache[file]
+	in.requireLock.Unlock()
+
+	if ok {
 		return evaluated
 	}
 
-	e := object.NewEnvironment(env.Writer, filepath.Dir(file), env.Version)
-	evaluated := doSource(tok, e, file, args...)
+	dir := filepath.Dir(file)
+
+	// standart kütüphane modüllerinin gerçek bir klasörü yok, göreli yollar çağıranın klasörüne göre çözülür
+	if strings.HasPrefix(file, "@") {
+		dir = env.Dir
+	}
+
+	e := object.NewEnvironment(env.Writer, dir, env.Version)
+	e.Runtime = in
+	evaluated = doSource(tok, e, file, args...)
 
 	
 	
@@ -2108,35 +2579,38 @@ func requireFn(tok token.Token, env *object.Environment, args ...object.Object)
 	case *object.Error:
 		return ret
 	default:
-		requireCache[file] = evaluated
+		in.requireLock.Lock()
+		in.requireCache[file] = evaluated
+		in.requireLock.Unlock()
 	}
 
 	return evaluated
 }
 
 func doSource(tok token.Token, env *object.Environment, fileName string, args ...object.Object) object.Object {
+	in := interpreterOf(env)
 	err := validateArgs(tok, "source", args, 1, [][]string{{object.STRING_OBJ}})
 	if err != nil {
 		
-		sourceLevel = 0
+		atomic.StoreInt32(&in.sourceLevel, 0)
 		return err
 	}
 
 	
 	sourceDepthStr := util.GetEnvVar(env, "ANK_SOURCE_DEPTH", ANK_SOURCE_DEPTH)
-	sourceDepth, _ = strconv.Atoi(sourceDepthStr)
+	sourceDepth, _ := strconv.Atoi(sourceDepthStr)
 
 	
-	if sourceLevel >= sourceDepth {
+	if int(atomic.LoadInt32(&in.sourceLevel)) >= sourceDepth {
 		
-		sourceLevel = 0
+		atomic.StoreInt32(&in.sourceLevel, 0)
 		
 		errObj := newError(tok, "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA", sourceDepth)
 		errObj = &object.Error{Message: errObj.Message}
 		return errObj
 	}
 	
-	sourceLevel++
+	atomic.AddInt32(&in.sourceLevel, 1)
 
 	var code []byte
 	var error error
@@ -2152,39 +2626,34 @@ func doSource(tok token.Token, env *object.Environment, fileName string, args ..
 
 	if error != nil {
 		
-		sourceLevel = 0
+		atomic.StoreInt32(&in.sourceLevel, 0)
 		
 		return newError(tok, "kaynak dosyası okunamadı: %s:\n%s", fileName, error.Error())
 	}
 	
-	l := lexer.New(string(code))
+	l := lexer.NewFile(string(code), fileName)
 	p := parser.New(l)
 	program := p.ParseProgram()
-	errors := p.Errors()
+	errors := p.ErrorList()
 	if len(errors) != 0 {
 		
-		sourceLevel = 0
-		errMsg := fmt.Sprintf("%s", " Ayrıştırıcı hatası:\n")
-		for _, msg := range errors {
-			errMsg += fmt.Sprintf("%s", "\t"+msg+"\n")
-		}
-		return newError(tok, "kaynak dosyasında hata çıktı: %s\n%s", fileName, errMsg)
+		atomic.StoreInt32(&in.sourceLevel, 0)
+
+		// hata modüldeki konumuyla döner, modülün yüklendiği yer yığına eklenir
+		err := newError(errors[0].Token, "ayrıştırıcı hatası: %s", errors[0].Message)
+		err.Stack = append(err.Stack, NewFrame("modül "+fileName, tok))
+		return err
 	}
 	
 	
 	
-	savedLexer := lex
-	evaluated := BeginEval(program, env, l)
-	lex = savedLexer
-	if evaluated != nil && evaluated.Type() == object.ERROR_OBJ {
-		
-		evalErrMsg := evaluated.(*object.Error).Message
-		sourceErrMsg := newError(tok, "eval bloğunda hata bulundu: %s", fileName).Message
-		errObj := &object.Error{Message: fmt.Sprintf("%s\n\t%s", sourceErrMsg, evalErrMsg)}
-		return errObj
+	evaluated := Eval(program, env)
+	atomic.AddInt32(&in.sourceLevel, -1)
+
+	// modüldeki hata kendi konumuyla döner, modülün yüklendiği yer yığına eklenir
+	if err, ok := evaluated.(*object.Error); ok {
+		err.Stack = append(err.Stack, NewFrame("modül "+fileName, tok))
 	}
-	
-	sourceLevel--
 
 	return evaluated
 }
@@ -2210,16 +2679,10 @@ func evalFn(tok token.Token, env *object.Environment, args ...object.Object) obj
 	
 	
 	
-	savedLexer := lex
-	evaluated := BeginEval(program, env, l)
-	lex = savedLexer
+	evaluated := Eval(program, env)
 
-	if evaluated != nil && evaluated.Type() == object.ERROR_OBJ {
-		
-		evalErrMsg := evaluated.(*object.Error).Message
-		sourceErrMsg := newError(tok, "Eval bloğunda hata bulundu: %s", args[0].Inspect()).Message
-		errObj := &object.Error{Message: fmt.Sprintf("%s\n\t%s", sourceErrMsg, evalErrMsg)}
-		return errObj
+	if err, ok := evaluated.(*object.Error); ok {
+		err.Stack = append(err.Stack, NewFrame("eval", tok))
 	}
 
 	return evaluated
@@ -2292,7 +2755,7 @@ func tsvFn(tok token.Token, env *object.Environment, args ...object.Object) obje
 		
 		
 		for _, rows := range array.Elements {
-			for _, pair := range rows.(*object.Hash).Pairs {
+			for _, pair := range rows.(*object.Hash).OrderedPairs() {
 				header = append(header, pair.Key.Inspect())
 			}
 		}
@@ -2386,3 +2849,298 @@ func execFn(tok token.Token, env *object.Environment, args ...object.Object) obj
 	}
 	return NULL
 }
+
+
+func resolvePath(env *object.Environment, path string) string {
+	path, _ = util.ExpandPath(path)
+
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(env.Dir, path)
+}
+
+
+func readFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "oku", args, [][][]string{
+		{{object.STRING_OBJ}, {object.STRING_OBJ}},
+		{{object.STRING_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	
+	if spec == 0 && args[1].(*object.String).Value != "bayt" {
+		return newError(tok, "oku(...) için bilinmeyen kip '%s', yalnızca \"bayt\" kullanılabilir", args[1].(*object.String).Value)
+	}
+
+	path := resolvePath(env, args[0].(*object.String).Value)
+	content, readErr := ioutil.ReadFile(path)
+
+	if readErr != nil {
+		return newError(tok, "%s okunamadı: %s", path, readErr.Error())
+	}
+
+	if spec == 0 {
+		return &object.Bytes{Token: tok, Value: content}
+	}
+
+	return &object.String{Token: tok, Value: string(content)}
+}
+
+
+
+
+// satır_oku'nun okuyabileceği en uzun satır
+const maxLineLength = 16 * 1024 * 1024
+
+func readLinesFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "satır_oku", args, 1, [][]string{{object.STRING_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	path := resolvePath(env, args[0].(*object.String).Value)
+	file, openErr := os.Open(path)
+
+	if openErr != nil {
+		return newError(tok, "%s okunamadı: %s", path, openErr.Error())
+	}
+
+	lines := bufio.NewScanner(file)
+	lines.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+	position := 0
+	closed := false
+
+	close := func() {
+		if !closed {
+			closed = true
+			file.Close()
+		}
+	}
+
+	next := func(env *object.Environment) (object.Object, object.Object) {
+		if closed {
+			return nil, EOF
+		}
+
+		if !lines.Scan() {
+			close()
+
+			if scanErr := lines.Err(); scanErr != nil {
+				return nil, newError(tok, "%s okunamadı: %s", path, scanErr.Error())
+			}
+
+			return nil, EOF
+		}
+
+		defer func() {
+			position++
+		}()
+
+		return object.NewInteger(tok, int64(position)), &object.String{Token: tok, Value: lines.Text()}
+	}
+
+	return &object.Builtin{
+		Token:    tok,
+		Iterable: true,
+		Next:     next,
+		// döngüden erken çıkılınca dosya kapanır
+		Close: close,
+		Fn: func(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+			if closed {
+				return newError(tok, "%s dosyası kapandı, okunacak satır kalmadı", path)
+			}
+
+			_, line := next(env)
+			if line == EOF {
+				return newError(tok, "%s dosyasında okunacak satır kalmadı", path)
+			}
+
+			return line
+		},
+	}
+}
+
+
+
+
+func globFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "listele", args, 1, [][]string{{object.STRING_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	pattern := resolvePath(env, args[0].(*object.String).Value)
+	var matches []string
+
+	if info, statErr := os.Stat(pattern); statErr == nil && info.IsDir() {
+		entries, readErr := ioutil.ReadDir(pattern)
+
+		if readErr != nil {
+			return newError(tok, "%s listelenemedi: %s", pattern, readErr.Error())
+		}
+
+		for _, entry := range entries {
+			matches = append(matches, filepath.Join(pattern, entry.Name()))
+		}
+	} else {
+		var globErr error
+		matches, globErr = filepath.Glob(pattern)
+
+		if globErr != nil {
+			return newError(tok, "geçersiz desen %s: %s", pattern, globErr.Error())
+		}
+	}
+
+	elements := make([]object.Object, len(matches))
+	for i, match := range matches {
+		elements[i] = &object.String{Token: tok, Value: match}
+	}
+
+	return &object.Array{Elements: elements}
+}
+
+
+func existsFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "var_mı", args, 1, [][]string{{object.STRING_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	_, statErr := os.Stat(resolvePath(env, args[0].(*object.String).Value))
+
+	return nativeBoolToBooleanObject(statErr == nil)
+}
+
+
+func statFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "bilgi", args, 1, [][]string{{object.STRING_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	path := resolvePath(env, args[0].(*object.String).Value)
+	info, statErr := os.Stat(path)
+
+	if statErr != nil {
+		return newError(tok, "%s bilgisi alınamadı: %s", path, statErr.Error())
+	}
+
+	return hashFromEntries(tok, []hashEntry{
+		{"ad", &object.String{Token: tok, Value: info.Name()}},
+		{"yol", &object.String{Token: tok, Value: path}},
+		{"boyut", object.NewInteger(tok, info.Size())},
+		{"klasör", nativeBoolToBooleanObject(info.IsDir())},
+		{"izinler", &object.String{Token: tok, Value: info.Mode().String()}},
+		{"değiştirme", object.NewInteger(tok, info.ModTime().UnixNano()/1000000)},
+	})
+}
+
+
+func mkdirFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "klasör_oluştur", args, 1, [][]string{{object.STRING_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	path := resolvePath(env, args[0].(*object.String).Value)
+
+	if mkdirErr := os.MkdirAll(path, 0755); mkdirErr != nil {
+		return newError(tok, "%s oluşturulamadı: %s", path, mkdirErr.Error())
+	}
+
+	return TRUE
+}
+
+
+
+func removeFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "sil", args, [][][]string{
+		{{object.STRING_OBJ}, {object.BOOLEAN_OBJ}},
+		{{object.STRING_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	path := resolvePath(env, args[0].(*object.String).Value)
+	remove := os.Remove
+
+	if spec == 0 && args[1].(*object.Boolean).Value {
+		remove = os.RemoveAll
+	}
+
+	if removeErr := remove(path); removeErr != nil {
+		return newError(tok, "%s silinemedi: %s", path, removeErr.Error())
+	}
+
+	return TRUE
+}
+
+
+func renameFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "taşı", args, 2, [][]string{{object.STRING_OBJ}, {object.STRING_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	from := resolvePath(env, args[0].(*object.String).Value)
+	to := resolvePath(env, args[1].(*object.String).Value)
+
+	if renameErr := os.Rename(from, to); renameErr != nil {
+		return newError(tok, "%s, %s konumuna taşınamadı: %s", from, to, renameErr.Error())
+	}
+
+	return TRUE
+}
+
+
+func copyFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "kopyala", args, 2, [][]string{{object.STRING_OBJ}, {object.STRING_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	from := resolvePath(env, args[0].(*object.String).Value)
+	to := resolvePath(env, args[1].(*object.String).Value)
+
+	if copyErr := copyFile(from, to); copyErr != nil {
+		return newError(tok, "%s, %s konumuna kopyalanamadı: %s", from, to, copyErr.Error())
+	}
+
+	return TRUE
+}
+
+func copyFile(from string, to string) error {
+	source, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	info, err := source.Stat()
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		return fmt.Errorf("%s bir klasör", from)
+	}
+
+	destination, err := os.OpenFile(to, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode())
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(destination, source); err != nil {
+		destination.Close()
+		return err
+	}
+
+	return destination.Close()
+}
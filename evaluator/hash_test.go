@@ -0,0 +1,45 @@
+package evaluator
+
+import "testing"
+
+// haritalar anahtarları eklenme sırasıyla tutar; silinip yeniden eklenen
+// anahtar sona geçer
+func TestHashOrder(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{`{"c": 1, "a": 2, "b": 3}.anahtarlar()`, `["c", "a", "b"]`},
+		{`h = {}; h["z"] = 1; h["y"] = 2; h[3] = 3; h.anahtarlar()`, `["z", "y", 3]`},
+		{`h = {"a": 1, "b": 2, "c": 3}; h.çıkar("b"); h.anahtarlar()`, `["a", "c"]`},
+		{`h = {"a": 1, "b": 2, "c": 3}; h.çıkar("a"); h["a"] = 4; h`, `{"b": 2, "c": 3, "a": 4}`},
+		{`h = {"a": 1, "b": 2}; h["a"] = 3; h`, `{"a": 3, "b": 2}`},
+		{`
+h = {}
+döngü i = 0; i < 100; i = i + 1 { h[i] = i }
+döngü i = 0; i < 97; i = i + 1 { h.çıkar(i) }
+h[5] = 5
+h[98] = 0
+h
+`, `{97: 97, 98: 0, 99: 99, 5: 5}`},
+		{`
+h = {"a": 1, "b": 2, "c": 3, "d": 4}
+döngü k in ["a", "b", "c", "d"] { h.çıkar(k) }
+h["e"] = 5
+h
+`, `{"e": 5}`},
+		{`
+h = {"a": 1, "b": 2, "c": 3}
+k = []
+döngü a, _ in h {
+    h.çıkar("b")
+    k.it(a)
+}
+k
+`, `["a", "c"]`},
+	}
+
+	for _, tt := range tests {
+		expect(t, tt.code, tt.want)
+	}
+}
@@ -0,0 +1,258 @@
+package evaluator
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+const ANK_HTTP_TIMEOUT = 30000
+
+func httpRequestFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "http_istek", args, [][][]string{
+		{{object.STRING_OBJ}, {object.STRING_OBJ}, {object.HASH_OBJ}},
+		{{object.STRING_OBJ}, {object.STRING_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	method := strings.ToUpper(args[0].(*object.String).Value)
+	target := args[1].(*object.String).Value
+	options := &object.Hash{}
+
+	if spec == 0 {
+		options = args[2].(*object.Hash)
+	}
+
+	parsed, parseErr := url.Parse(target)
+	if parseErr != nil {
+		return newError(tok, "geçersiz URL %s: %s", target, parseErr.Error())
+	}
+
+	if query, ok := options.GetPair("sorgu"); ok {
+		params, ok := query.Value.(*object.Hash)
+		if !ok {
+			return newError(tok, "\"sorgu\" seçeneği bir harita olmalı, bulunan %s", query.Value.Type())
+		}
+
+		values := parsed.Query()
+		for _, pair := range params.OrderedPairs() {
+			if list, ok := pair.Value.(*object.Array); ok {
+				for _, v := range list.Elements {
+					values.Add(pair.Key.Inspect(), v.Inspect())
+				}
+				continue
+			}
+
+			values.Set(pair.Key.Inspect(), pair.Value.Inspect())
+		}
+		parsed.RawQuery = values.Encode()
+	}
+
+	var body io.Reader
+	contentType := ""
+
+	if payload, ok := options.GetPair("json"); ok {
+		encoded := encodeJSON(tok, payload.Value, "")
+		if isError(encoded) {
+			return encoded
+		}
+
+		body = strings.NewReader(encoded.(*object.String).Value)
+		contentType = "application/json"
+	} else if payload, ok := options.GetPair("gövde"); ok {
+		body = strings.NewReader(payload.Value.Inspect())
+	}
+
+	req, reqErr := http.NewRequest(method, parsed.String(), body)
+	if reqErr != nil {
+		return newError(tok, "HTTP isteği oluşturulamadı: %s", reqErr.Error())
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if headers, ok := options.GetPair("başlıklar"); ok {
+		hash, ok := headers.Value.(*object.Hash)
+		if !ok {
+			return newError(tok, "\"başlıklar\" seçeneği bir harita olmalı, bulunan %s", headers.Value.Type())
+		}
+
+		for _, pair := range hash.OrderedPairs() {
+			req.Header.Set(pair.Key.Inspect(), pair.Value.Inspect())
+		}
+	}
+
+	timeout := float64(ANK_HTTP_TIMEOUT)
+	if t, ok := options.GetPair("zaman_aşımı"); ok {
+		n, ok := t.Value.(*object.Number)
+		if !ok {
+			return newError(tok, "\"zaman_aşımı\" seçeneği milisaniye cinsinden bir sayı olmalı, bulunan %s", t.Value.Type())
+		}
+
+		timeout = n.Value
+	}
+
+	client := &http.Client{Timeout: time.Duration(timeout) * time.Millisecond}
+	resp, doErr := client.Do(req)
+	if doErr != nil {
+		return newError(tok, "HTTP isteği başarısız oldu: %s", doErr.Error())
+	}
+	defer resp.Body.Close()
+
+	response := hashFromEntries(tok, []hashEntry{
+		{"durum", object.NewInteger(tok, int64(resp.StatusCode))},
+		{"başlıklar", httpHeadersToHash(tok, resp.Header)},
+	})
+
+	if file, ok := options.GetPair("dosya"); ok {
+		path := resolvePath(env, file.Value.Inspect())
+		out, createErr := os.Create(path)
+		if createErr != nil {
+			return newError(tok, "%s oluşturulamadı: %s", path, createErr.Error())
+		}
+
+		_, copyErr := io.Copy(out, resp.Body)
+		closeErr := out.Close()
+
+		if copyErr == nil {
+			copyErr = closeErr
+		}
+
+		if copyErr != nil {
+			return newError(tok, "HTTP yanıtı %s dosyasına yazılamadı: %s", path, copyErr.Error())
+		}
+
+		response.Set(&object.String{Token: tok, Value: "dosya"}, &object.String{Token: tok, Value: path})
+		return response
+	}
+
+	content, readErr := ioutil.ReadAll(resp.Body)
+	if readErr != nil {
+		return newError(tok, "HTTP yanıtı okunamadı: %s", readErr.Error())
+	}
+
+	response.Set(&object.String{Token: tok, Value: "gövde"}, &object.String{Token: tok, Value: string(content)})
+	return response
+}
+
+func httpHeadersToHash(tok token.Token, headers http.Header) *object.Hash {
+	hash := &object.Hash{Token: tok}
+
+	for name, values := range headers {
+		hash.Set(&object.String{Token: tok, Value: name}, &object.String{Token: tok, Value: strings.Join(values, ", ")})
+	}
+
+	return hash
+}
+
+func httpServeFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "http_sunucu", args, 2, [][]string{{object.STRING_OBJ}, {object.FUNCTION_OBJ, object.BUILTIN_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	address := args[0].(*object.String).Value
+	serveErr := http.ListenAndServe(address, httpHandler(tok, env, args[1]))
+
+	return newError(tok, "HTTP sunucusu %s adresinde çalıştırılamadı: %s", address, serveErr.Error())
+}
+
+// httpHandler gelen her isteği bir harita olarak handler'a verir ve dönen
+// değeri yanıt olarak yazar. İstekler aynı anda işlenir; haritalar, diziler
+// ve ortamlar eşzamanlı erişime karşı korunduğu için işleyiciler ortak
+// değerleri değiştirebilir, ama birkaç adımlık güncellemeler (ör. oku, artır,
+// yaz) bir kanal ya da tek bir görevle sıraya konmalıdır.
+func httpHandler(tok token.Token, env *object.Environment, handler object.Object) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, readErr := ioutil.ReadAll(r.Body)
+		if readErr != nil {
+			http.Error(w, readErr.Error(), http.StatusBadRequest)
+			return
+		}
+
+		query := &object.Hash{Token: tok}
+		for name, values := range r.URL.Query() {
+			query.Set(&object.String{Token: tok, Value: name}, &object.String{Token: tok, Value: values[0]})
+		}
+
+		request := hashFromEntries(tok, []hashEntry{
+			{"yöntem", &object.String{Token: tok, Value: r.Method}},
+			{"yol", &object.String{Token: tok, Value: r.URL.Path}},
+			{"sorgu", query},
+			{"başlıklar", httpHeadersToHash(tok, r.Header)},
+			{"gövde", &object.String{Token: tok, Value: string(content)}},
+		})
+
+		writeHTTPResponse(tok, w, applyFunction(tok, handler, env, []object.Object{request}))
+	})
+}
+
+func writeHTTPResponse(tok token.Token, w http.ResponseWriter, response object.Object) {
+	status := http.StatusOK
+	var body object.Object = response
+	asJSON := false
+
+	switch res := response.(type) {
+	case *object.Error:
+		http.Error(w, res.Text, http.StatusInternalServerError)
+		return
+	case *object.Null:
+		body = &object.String{Token: tok, Value: ""}
+	case *object.Hash:
+		if s, ok := res.GetPair("durum"); ok {
+			n, ok := s.Value.(*object.Number)
+			if !ok {
+				http.Error(w, "\"durum\" bir sayı olmalı", http.StatusInternalServerError)
+				return
+			}
+
+			status = n.Int()
+		}
+
+		if h, ok := res.GetPair("başlıklar"); ok {
+			if headers, ok := h.Value.(*object.Hash); ok {
+				for _, pair := range headers.OrderedPairs() {
+					w.Header().Set(pair.Key.Inspect(), pair.Value.Inspect())
+				}
+			}
+		}
+
+		body = &object.String{Token: tok, Value: ""}
+
+		if b, ok := res.GetPair("json"); ok {
+			body = b.Value
+			asJSON = true
+		} else if b, ok := res.GetPair("gövde"); ok {
+			body = &object.String{Token: tok, Value: b.Value.Inspect()}
+		}
+	}
+
+	content, ok := body.(*object.String)
+	if !ok || asJSON {
+		encoded := encodeJSON(tok, body, "")
+		if isError(encoded) {
+			http.Error(w, encoded.(*object.Error).Message, http.StatusInternalServerError)
+			return
+		}
+
+		content = encoded.(*object.String)
+
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", "application/json")
+		}
+	}
+
+	w.WriteHeader(status)
+	io.WriteString(w, content.Value)
+}
@@ -0,0 +1,208 @@
+package evaluator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ankalang/anka/lexer"
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/parser"
+	"github.com/ankalang/anka/token"
+)
+
+// serve kodu çalıştırır ve kodun tanımladığı işle fonksiyonunu bir test
+// sunucusunda dinletir
+func serve(t *testing.T, code string) *httptest.Server {
+	t.Helper()
+
+	p := parser.New(lexer.New(code))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		t.Fatalf("ayrıştırıcı hatası: %s", strings.Join(p.Errors(), "\n"))
+	}
+
+	env := object.NewEnvironment(ioutil.Discard, ".", "test")
+	if result := NewInterpreter(strings.NewReader("")).Run(program, env); isError(result) {
+		t.Fatal(result.Inspect())
+	}
+
+	handler, ok := env.Get("işle")
+	if !ok {
+		t.Fatal("kod işle fonksiyonunu tanımlamalı")
+	}
+
+	return httptest.NewServer(httpHandler(token.Token{}, env, handler))
+}
+
+func TestHTTPServer(t *testing.T) {
+	server := serve(t, `
+http = src("@http")
+sunucu = http.sunucu()
+
+@sunucu.yol("GET", "/merhaba/:ad")
+f selam(istek) { dön "merhaba " + istek.parametreler.ad }
+
+@sunucu.yol("POST", "/yankı")
+f yankı(istek) {
+    dön {"durum": 201, "json": {"gövde": istek.gövde, "sorgu": istek.sorgu}}
+}
+
+@sunucu.yol("GET", "/başlık")
+f başlık(istek) {
+    dön {"başlıklar": {"X-Anka": istek.başlıklar["X-Deneme"]}, "gövde": "tamam"}
+}
+
+@sunucu.yol("GET", "/boş")
+f boş(istek) { dön null }
+
+@sunucu.yol("GET", "/hata")
+f patla(istek) { dön olmayan }
+
+@sunucu.yol("GET", "/kötü")
+f kötü(istek) { dön {"durum": "yüz"} }
+
+işle = sunucu.işle
+`)
+	defer server.Close()
+
+	tests := []struct {
+		method     string
+		path       string
+		body       string
+		status     int
+		want       string
+		header     string
+		wantHeader string
+	}{
+		{"GET", "/merhaba/ali", "", 200, "merhaba ali", "", ""},
+		{"POST", "/yankı?x=1", "abc", 201, `{"gövde": "abc", "sorgu": {"x": "1"}}`, "Content-Type", "application/json"},
+		{"GET", "/başlık", "", 200, "tamam", "X-Anka", "evet"},
+		{"GET", "/boş", "", 200, "", "", ""},
+		{"GET", "/yok", "", 404, "bulunamadı: GET /yok", "", ""},
+		{"DELETE", "/merhaba/ali", "", 404, "bulunamadı: DELETE /merhaba/ali", "", ""},
+		{"GET", "/hata", "", 500, "Bulunamadı: olmayan\n", "", ""},
+		{"GET", "/kötü", "", 500, "\"durum\" bir sayı olmalı\n", "", ""},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("X-Deneme", "evet")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s %s: durum %d, istenen %d", tt.method, tt.path, resp.StatusCode, tt.status)
+		}
+
+		if string(body) != tt.want {
+			t.Errorf("%s %s: gövde %q, istenen %q", tt.method, tt.path, body, tt.want)
+		}
+
+		if tt.header != "" && resp.Header.Get(tt.header) != tt.wantHeader {
+			t.Errorf("%s %s: %s başlığı %q, istenen %q", tt.method, tt.path, tt.header, resp.Header.Get(tt.header), tt.wantHeader)
+		}
+	}
+}
+
+// istekler sırayla değil aynı anda işlenir: ilk istek ikincisinin
+// göndereceği değeri bekler
+func TestHTTPServerHandlesRequestsConcurrently(t *testing.T) {
+	server := serve(t, `
+k = kanal()
+f işle(istek) {
+    eğer istek.yol == "/bekle" {
+        dön "alındı " + str(k.al())
+    }
+
+    k.gönder(42)
+    dön "gönderildi"
+}
+`)
+	defer server.Close()
+
+	waiting := make(chan string)
+	go func() {
+		resp, err := http.Get(server.URL + "/bekle")
+		if err != nil {
+			waiting <- err.Error()
+			return
+		}
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		waiting <- string(body)
+	}()
+
+	resp, err := http.Get(server.URL + "/gönder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if got := <-waiting; got != "alındı 42" {
+		t.Errorf("bulunan %q", got)
+	}
+}
+
+func TestHTTPRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		if r.URL.Path == "/yok" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+
+		w.Header().Set("X-Yol", r.URL.Path)
+		fmt.Fprintf(w, "%s %s?%s %s %s %s", r.Method, r.URL.Path, r.URL.RawQuery, r.Header.Get("Content-Type"), r.Header.Get("X-Deneme"), body)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		code string
+		want string
+	}{
+		{`http_istek("GET", "%s/a").gövde`, "GET /a?   "},
+		{`http_istek("get", "%s/a", {"sorgu": {"b": 1, "c": [2, 3]}}).gövde`, "GET /a?b=1&c=2&c=3   "},
+		{`http_istek("POST", "%s/a", {"json": {"x": 1}}).gövde`, `POST /a? application/json  {"x": 1}`},
+		{`http_istek("PUT", "%s/a", {"gövde": "düz", "başlıklar": {"X-Deneme": "evet"}}).gövde`, "PUT /a?  evet düz"},
+		{`http_istek("GET", "%s/yok").durum`, "404"},
+		{`http_istek("GET", "%s/a").başlıklar["X-Yol"]`, "/a"},
+	}
+
+	for _, tt := range tests {
+		expect(t, fmt.Sprintf(tt.code, server.URL), tt.want)
+	}
+
+	errors := []struct {
+		code    string
+		message string
+	}{
+		{`http_istek("GET", "%s/a", {"sorgu": 1})`, `"sorgu" seçeneği bir harita olmalı`},
+		{`http_istek("GET", "%s/a", {"başlıklar": "x"})`, `"başlıklar" seçeneği bir harita olmalı`},
+		{`http_istek("GET", "%s/a", {"zaman_aşımı": "x"})`, `"zaman_aşımı" seçeneği milisaniye cinsinden bir sayı olmalı`},
+		{`http_istek("GET", "://adres")`, "geçersiz URL"},
+		{`http_istek("GET", "http://127.0.0.1:0/")`, "HTTP isteği başarısız oldu"},
+	}
+
+	for _, tt := range errors {
+		code := tt.code
+		if strings.Contains(code, "%s") {
+			code = fmt.Sprintf(code, server.URL)
+		}
+
+		expectError(t, code, tt.message)
+	}
+}
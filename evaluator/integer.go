@@ -0,0 +1,217 @@
+package evaluator
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+// üs alma ve sola kaydırmada izin verilen en büyük sonuç boyutu (bit)
+const maxIntegerBits = 1 << 24
+
+// evalIntegerInfixExpression iki tamsayı arasındaki işlemleri kesin olarak yapar.
+// Sonucu tamsayı olmayan işlemler (ör. 7 / 2) için false döner ve ondalıklı
+// hesaplamaya bırakır.
+func evalIntegerInfixExpression(tok token.Token, operator string, left, right *object.Number) (object.Object, bool) {
+	if left.Big == nil && right.Big == nil {
+		if result, ok := evalSmallIntegerInfixExpression(tok, operator, left.Small, right.Small); ok {
+			return result, true
+		}
+	}
+
+	l, r := left.BigInt(), right.BigInt()
+
+	switch operator {
+	case "+":
+		return object.NewBigInteger(tok, new(big.Int).Add(l, r)), true
+	case "-":
+		return object.NewBigInteger(tok, new(big.Int).Sub(l, r)), true
+	case "*":
+		return object.NewBigInteger(tok, new(big.Int).Mul(l, r)), true
+	case "/":
+		if r.Sign() == 0 {
+			return nil, false
+		}
+
+		quotient, remainder := new(big.Int).QuoRem(l, r, new(big.Int))
+		if remainder.Sign() == 0 {
+			return object.NewBigInteger(tok, quotient), true
+		}
+
+		f, _ := new(big.Rat).SetFrac(l, r).Float64()
+		return &object.Number{Token: tok, Value: f}, true
+	case "%":
+		if r.Sign() == 0 {
+			return nil, false
+		}
+
+		return object.NewBigInteger(tok, new(big.Int).Rem(l, r)), true
+	case "**":
+		if r.Sign() < 0 {
+			return nil, false
+		}
+
+		// üs önce tek başına sınanır, çarpım int64'te taşmasın
+		if !r.IsInt64() || r.Int64() > maxIntegerBits || int64(l.BitLen())*r.Int64() > maxIntegerBits {
+			if l.CmpAbs(big.NewInt(1)) > 0 {
+				return newError(tok, "%s ** %s sonucu çok büyük", left.Inspect(), right.Inspect()), true
+			}
+		}
+
+		return object.NewBigInteger(tok, new(big.Int).Exp(l, r, nil)), true
+	case "<", ">", "<=", ">=", "==", "!=", "~", "<=>":
+		return compareIntegers(tok, operator, l.Cmp(r)), true
+	case "&":
+		return object.NewBigInteger(tok, new(big.Int).And(l, r)), true
+	case "|":
+		return object.NewBigInteger(tok, new(big.Int).Or(l, r)), true
+	case "^":
+		return object.NewBigInteger(tok, new(big.Int).Xor(l, r)), true
+	case "<<", ">>":
+		if r.Sign() < 0 {
+			return newError(tok, "Negatif kaydırma miktarı: %s", right.Inspect()), true
+		}
+
+		if operator == ">>" {
+			if !r.IsInt64() || r.Int64() > int64(l.BitLen()) {
+				if l.Sign() < 0 {
+					return object.NewInteger(tok, -1), true
+				}
+
+				return object.NewInteger(tok, 0), true
+			}
+
+			return object.NewBigInteger(tok, new(big.Int).Rsh(l, uint(r.Int64()))), true
+		}
+
+		if l.Sign() == 0 {
+			return object.NewInteger(tok, 0), true
+		}
+
+		if !r.IsInt64() || r.Int64() > maxIntegerBits || int64(l.BitLen())+r.Int64() > maxIntegerBits {
+			return newError(tok, "%s << %s sonucu çok büyük", left.Inspect(), right.Inspect()), true
+		}
+
+		return object.NewBigInteger(tok, new(big.Int).Lsh(l, uint(r.Int64()))), true
+	}
+
+	return nil, false
+}
+
+// int64'e sığan işlemler math/big'e uğramadan yapılır, taşma olursa false döner
+func evalSmallIntegerInfixExpression(tok token.Token, operator string, l, r int64) (object.Object, bool) {
+	switch operator {
+	case "+":
+		sum := l + r
+		if (l > 0 && r > 0 && sum < 0) || (l < 0 && r < 0 && sum >= 0) {
+			return nil, false
+		}
+
+		return object.NewInteger(tok, sum), true
+	case "-":
+		difference := l - r
+		if (l >= 0 && r < 0 && difference < 0) || (l < 0 && r > 0 && difference >= 0) {
+			return nil, false
+		}
+
+		return object.NewInteger(tok, difference), true
+	case "*":
+		if l == 0 || r == 0 {
+			return object.NewInteger(tok, 0), true
+		}
+
+		product := l * r
+		if product/r != l || (l == -1 && r == math.MinInt64) || (r == -1 && l == math.MinInt64) {
+			return nil, false
+		}
+
+		return object.NewInteger(tok, product), true
+	case "<", ">", "<=", ">=", "==", "!=", "~", "<=>":
+		cmp := 0
+		if l < r {
+			cmp = -1
+		} else if l > r {
+			cmp = 1
+		}
+
+		return compareIntegers(tok, operator, cmp), true
+	case "&":
+		return object.NewInteger(tok, l&r), true
+	case "|":
+		return object.NewInteger(tok, l|r), true
+	case "^":
+		return object.NewInteger(tok, l^r), true
+	}
+
+	return nil, false
+}
+
+func compareIntegers(tok token.Token, operator string, cmp int) object.Object {
+	switch operator {
+	case "<":
+		return nativeBoolToBooleanObject(cmp < 0)
+	case ">":
+		return nativeBoolToBooleanObject(cmp > 0)
+	case "<=":
+		return nativeBoolToBooleanObject(cmp <= 0)
+	case ">=":
+		return nativeBoolToBooleanObject(cmp >= 0)
+	case "!=":
+		return nativeBoolToBooleanObject(cmp != 0)
+	case "<=>":
+		return object.NewInteger(tok, int64(cmp))
+	default:
+		return nativeBoolToBooleanObject(cmp == 0)
+	}
+}
+
+// compareNumbers iki tamsayıyı kesin, diğer durumları float64 üzerinden karşılaştırır
+func compareNumbers(a, b *object.Number) int {
+	if a.Integer && b.Integer {
+		return a.BigInt().Cmp(b.BigInt())
+	}
+
+	switch {
+	case a.Value < b.Value:
+		return -1
+	case a.Value > b.Value:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// truncateToInteger ondalıklı bir sayının tam kısmını tamsayı olarak döner;
+// NaN ve sonsuzluk tamsayıya çevrilemediği için olduğu gibi kalır
+func truncateToInteger(tok token.Token, v float64) *object.Number {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return &object.Number{Token: tok, Value: v}
+	}
+
+	if v > math.MinInt64 && v < math.MaxInt64 {
+		return object.NewInteger(tok, int64(v))
+	}
+
+	i, _ := big.NewFloat(v).Int(nil)
+	return object.NewBigInteger(tok, i)
+}
+
+// parseNumber "42" gibi tamsayıları kesin, "4.2" ya da "1e3" gibi sayıları ondalıklı okur
+func parseNumber(tok token.Token, s string) (*object.Number, bool) {
+	if !strings.ContainsAny(s, ".eEnN") {
+		if i, ok := new(big.Int).SetString(s, 10); ok {
+			return object.NewBigInteger(tok, i), true
+		}
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	return &object.Number{Token: tok, Value: f}, true
+}
@@ -0,0 +1,48 @@
+package evaluator
+
+import "testing"
+
+func TestIntegerPromotion(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"9223372036854775807 + 1", "9223372036854775808"},
+		{"-9223372036854775808 - 1", "-9223372036854775809"},
+		{"4294967296 * 4294967296", "18446744073709551616"},
+		{"2 ** 64", "18446744073709551616"},
+		{"2 ** 64 - 2 ** 64 + 7", "7"},
+		{"(2 ** 64) / (2 ** 32)", "4294967296"},
+		{"(2 ** 64) % 10", "6"},
+		{"1 << 70", "1180591620717411303424"},
+		{"(1 << 70) >> 68", "4"},
+		{"-1 >> 100", "-1"},
+		{"1 ** 4611686018427387904", "1"},
+		{"(-1) ** 9223372036854775807", "-1"},
+		{"2 ** 64 > 2 ** 63", "true"},
+	}
+
+	for _, tt := range tests {
+		expect(t, tt.code, tt.want)
+	}
+}
+
+func TestIntegerResultTooLarge(t *testing.T) {
+	tests := []struct {
+		code    string
+		message string
+	}{
+		// üssün bit uzunluğuyla çarpımı int64'te taşardı
+		{"3 ** 4611686018427387904", "sonucu çok büyük"},
+		{"3 ** 92233720368547758070", "sonucu çok büyük"},
+		{"2 ** 16777217", "sonucu çok büyük"},
+		// kaydırma miktarına eklenen bit uzunluğu int64'te taşardı
+		{"1 << 9223372036854775807", "sonucu çok büyük"},
+		{"1 << 16777217", "sonucu çok büyük"},
+		{"1 << -1", "Negatif kaydırma miktarı"},
+	}
+
+	for _, tt := range tests {
+		expectError(t, tt.code, tt.message)
+	}
+}
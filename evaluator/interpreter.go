@@ -0,0 +1,71 @@
+package evaluator
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/ankalang/anka/ast"
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/util"
+)
+
+// Interpreter bir programın yorumlayıcı durumunu taşır: modül önbelleği, paket
+// takma adları, kaynak ve çağrı derinliği sınırları ve standart girdi. Aynı
+// süreçte birden fazla program, her biri kendi Interpreter'ıyla birbirinden
+// bağımsız çalışabilir.
+type Interpreter struct {
+	scanner         *bufio.Scanner
+	scannerPosition int
+	scannerLock     sync.Mutex
+
+	requireCache         map[string]object.Object
+	packageAliases       map[string]string
+	packageAliasesLoaded bool
+	requireLock          sync.Mutex
+
+	sourceLevel int32
+
+	callLimit     int
+	callLimitOnce sync.Once
+}
+
+func NewInterpreter(stdin io.Reader) *Interpreter {
+	return &Interpreter{
+		scanner:      bufio.NewScanner(stdin),
+		requireCache: make(map[string]object.Object),
+	}
+}
+
+// Run programı env içinde çalıştırır; env'den türeyen tüm ortamlar bu yorumlayıcıya bağlı kalır
+func (in *Interpreter) Run(program ast.Node, env *object.Environment) object.Object {
+	in.Attach(env)
+	return Eval(program, env)
+}
+
+// Attach ortamı yorumlayıcıya bağlar; ortamda başka bir motor (vm) çalışırken
+// modüller ve standart girdi bu yorumlayıcının durumunu kullanır
+func (in *Interpreter) Attach(env *object.Environment) {
+	env.Runtime = in
+}
+
+// maxCallDepth iç içe çağrı sınırını döner; ANK_CALL_DEPTH ilk çağrıda okunur
+func (in *Interpreter) maxCallDepth(env *object.Environment) int {
+	in.callLimitOnce.Do(func() {
+		in.callLimit, _ = strconv.Atoi(util.GetEnvVar(env, "ANK_CALL_DEPTH", ANK_CALL_DEPTH))
+	})
+
+	return in.callLimit
+}
+
+func interpreterOf(env *object.Environment) *Interpreter {
+	if in, ok := env.Runtime.(*Interpreter); ok {
+		return in
+	}
+
+	in := NewInterpreter(os.Stdin)
+	env.Runtime = in
+	return in
+}
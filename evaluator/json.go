@@ -0,0 +1,205 @@
+package evaluator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+type jsonError struct {
+	offset  int64
+	message string
+}
+
+func (e *jsonError) Error() string { return e.message }
+
+func decodeJSON(tok token.Token, input string) object.Object {
+	decoder := json.NewDecoder(strings.NewReader(input))
+	decoder.UseNumber()
+
+	value, err := decodeJSONValue(tok, decoder)
+
+	if err == nil {
+		switch _, trailing := decoder.Token(); trailing {
+		case io.EOF:
+		case nil:
+			err = &jsonError{offset: decoder.InputOffset(), message: "JSON değerinden sonra beklenmeyen veri"}
+		default:
+			err = trailing
+		}
+	}
+
+	if err != nil {
+		line, column := jsonErrorPosition(input, err)
+		return newError(tok, "JSON ayrıştırılamadı (satır %d, sütun %d): %s", line, column, jsonErrorMessage(err))
+	}
+
+	return value
+}
+
+func decodeJSONValue(tok token.Token, decoder *json.Decoder) (object.Object, error) {
+	t, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := t.(type) {
+	case json.Delim:
+		switch v {
+		case '{':
+			hash := &object.Hash{Token: tok}
+
+			for decoder.More() {
+				k, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+
+				key := &object.String{Token: tok, Value: k.(string)}
+				value, err := decodeJSONValue(tok, decoder)
+				if err != nil {
+					return nil, err
+				}
+
+				hash.Set(key, value)
+			}
+
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+
+			return hash, nil
+		case '[':
+			elements := []object.Object{}
+
+			for decoder.More() {
+				value, err := decodeJSONValue(tok, decoder)
+				if err != nil {
+					return nil, err
+				}
+
+				elements = append(elements, value)
+			}
+
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+
+			return &object.Array{Token: tok, Elements: elements}, nil
+		}
+	case json.Number:
+		n, ok := parseNumber(tok, string(v))
+		if !ok {
+			return nil, &jsonError{offset: decoder.InputOffset(), message: fmt.Sprintf("geçersiz sayı: %s", v)}
+		}
+
+		return n, nil
+	case string:
+		return &object.String{Token: tok, Value: v}, nil
+	case bool:
+		return nativeBoolToBooleanObject(v), nil
+	case nil:
+		return NULL, nil
+	}
+
+	return nil, &jsonError{offset: decoder.InputOffset(), message: fmt.Sprintf("beklenmeyen token: %v", t)}
+}
+
+func jsonErrorPosition(input string, err error) (int, int) {
+	offset := int64(len(input))
+
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		offset = e.Offset
+	case *jsonError:
+		offset = e.offset
+	}
+
+	if offset > int64(len(input)) {
+		offset = int64(len(input))
+	}
+
+	consumed := input[:offset]
+	line := strings.Count(consumed, "\n") + 1
+	column := len([]rune(consumed[strings.LastIndex(consumed, "\n")+1:]))
+
+	if column == 0 {
+		column = 1
+	}
+
+	return line, column
+}
+
+func jsonErrorMessage(err error) string {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return "beklenmeyen veri sonu"
+	}
+
+	return err.Error()
+}
+
+func encodeJSON(tok token.Token, obj object.Object, indent string) object.Object {
+	if err := checkJSONKeys(tok, obj); err != nil {
+		return err
+	}
+
+	encoded := []byte(obj.Json())
+
+	if !json.Valid(encoded) {
+		return newError(tok, "%s tipindeki değer JSON'a dönüştürülemez: %s", obj.Type(), obj.Inspect())
+	}
+
+	if indent == "" {
+		return &object.String{Token: tok, Value: string(encoded)}
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, encoded, "", indent); err != nil {
+		return newError(tok, "JSON biçimlendirilemedi: %s", err.Error())
+	}
+
+	return &object.String{Token: tok, Value: out.String()}
+}
+
+// checkJSONKeys metne çevrildiğinde aynı olan anahtarları reddeder: {1: "a",
+// "1": "b"} JSON'da iki tane "1" anahtarı olurdu
+func checkJSONKeys(tok token.Token, obj object.Object) object.Object {
+	switch o := obj.(type) {
+	case *object.Hash:
+		seen := map[string]object.Object{}
+
+		for _, pair := range o.OrderedPairs() {
+			key := pair.Key.Inspect()
+			if other, ok := seen[key]; ok {
+				return newError(tok, "%s ve %s tipindeki anahtarlar JSON'da aynı \"%s\" anahtarı olur", other.Type(), pair.Key.Type(), key)
+			}
+
+			seen[key] = pair.Key
+
+			if err := checkJSONKeys(tok, pair.Value); err != nil {
+				return err
+			}
+		}
+	case *object.Array:
+		for _, e := range o.Elements {
+			if err := checkJSONKeys(tok, e); err != nil {
+				return err
+			}
+		}
+	case *object.Set:
+		for _, e := range o.Ordered() {
+			if err := checkJSONKeys(tok, e); err != nil {
+				return err
+			}
+		}
+	case *object.Instance:
+		return checkJSONKeys(tok, o.Fields)
+	}
+
+	return nil
+}
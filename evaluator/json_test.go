@@ -0,0 +1,28 @@
+package evaluator
+
+import "testing"
+
+func TestJSONDecode(t *testing.T) {
+	expect(t, `json('{"a": [1, 2.5, "x", true, null], "b": {"c": -3e2}}')`, `{"a": [1, 2.5, "x", true, null], "b": {"c": -300}}`)
+	expect(t, `json('"\u015f"')`, "ş")
+
+	expectError(t, `json('{"a": 1,}')`, "satır 1, sütun 8")
+	expectError(t, `json('[1, 2')`, "satır 1, sütun 5")
+	expectError(t, `json('{"a": 1} x')`, "satır 1, sütun 10")
+}
+
+func TestJSONEncode(t *testing.T) {
+	expect(t, `jsonla({"a": [1, "x"], "b": null})`, `{"a": [1, "x"], "b": null}`)
+	expect(t, `jsonla([1, {"a": 2}], 2)`, "[\n  1,\n  {\n    \"a\": 2\n  }\n]")
+	expect(t, `jsonla([1], "\t")`, "[\n\t1\n]")
+
+	expectError(t, `jsonla([1], -1)`, "negatif olmayan bir tamsayı olmalı, bulunan -1")
+	expectError(t, `jsonla([1], 1.5)`, "negatif olmayan bir tamsayı olmalı, bulunan 1.5")
+	expectError(t, `jsonla(f() {})`, "JSON'a dönüştürülemez")
+}
+
+func TestJSONEncodeCollidingKeys(t *testing.T) {
+	expectError(t, `jsonla({1: "a", "1": "b"})`, `NUMBER ve STRING tipindeki anahtarlar JSON'da aynı "1" anahtarı olur`)
+	expectError(t, `jsonla([{"x": {Doğru: 1, "true": 2}}])`, `aynı "true" anahtarı olur`)
+	expect(t, `jsonla({1: "a", "2": "b"})`, `{"1": "a", "2": "b"}`)
+}
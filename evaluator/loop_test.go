@@ -0,0 +1,96 @@
+package evaluator
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/ankalang/anka/lexer"
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/parser"
+)
+
+// fonksiyon oluşturmayan turlar aynı kapsamı boşaltıp kullanır; bir turda
+// tanımlanan sabit sonraki turu etkilememeli, fonksiyonlar kendi turlarını
+// yakalamalı
+func TestLoopIterationScopes(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{`
+d = []
+döngü i = 0; i < 3; i = i + 1 {
+    sabit k = i * 2
+    d.it(k)
+}
+d
+`, "[0, 2, 4]"},
+		{`
+fs = []
+döngü i = 0; i < 3; i = i + 1 {
+    değişken kare = i * i
+    fs.it(f() { kare })
+}
+fs.haritala(f(g) { g() })
+`, "[0, 1, 4]"},
+		{`
+fs = []
+döngü i = 0; i < 4; i = i + 1 {
+    eğer i % 2 == 0 {
+        fs.it(f() { i })
+    }
+}
+fs.haritala(f(g) { g() })
+`, "[0, 2]"},
+		{`
+fs = []
+döngü x in [1, 2, 3] {
+    sabit y = x + 1
+    eğer x != 2 { fs.it(f() { y }) }
+}
+fs.haritala(f(g) { g() })
+`, "[2, 4]"},
+	}
+
+	for _, tt := range tests {
+		expect(t, tt.code, tt.want)
+	}
+}
+
+func BenchmarkForLoop(b *testing.B) {
+	benchmarkCode(b, `
+t = 0
+döngü i = 0; i < 10000; i = i + 1 {
+    t = t + i
+}
+`)
+}
+
+func BenchmarkForInLoop(b *testing.B) {
+	benchmarkCode(b, `
+t = 0
+döngü x in 1..10000 {
+    t = t + x
+}
+`)
+}
+
+// benchmarkCode kodu bir kez ayrıştırıp her turda yeni bir ortamda çalıştırır
+func benchmarkCode(b *testing.B, code string) {
+	p := parser.New(lexer.New(code))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		b.Fatalf("ayrıştırıcı hatası: %s", strings.Join(p.Errors(), "\n"))
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		env := object.NewEnvironment(ioutil.Discard, ".", "test")
+		if result := NewInterpreter(strings.NewReader("")).Run(program, env); isError(result) {
+			b.Fatal(result.Inspect())
+		}
+	}
+}
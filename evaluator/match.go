@@ -0,0 +1,256 @@
+package evaluator
+
+import (
+	"github.com/ankalang/anka/ast"
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+// tip kalıplarında kullanılabilen yerleşik tip adları; bunların dışındaki
+// adlar tip ile tanımlanmış kullanıcı tipleri olarak aranır
+var patternTypes = map[string][]object.ObjectType{
+	"Sayı":      {object.NUMBER_OBJ},
+	"Tamsayı":   {object.NUMBER_OBJ},
+	"Ondalık":   {object.DECIMAL_OBJ},
+	"Yazı":      {object.STRING_OBJ},
+	"Mantıksal": {object.BOOLEAN_OBJ},
+	"Dizi":      {object.ARRAY_OBJ},
+	"Harita":    {object.HASH_OBJ},
+	"Küme":      {object.SET_OBJ},
+	"Bayt":      {object.BYTES_OBJ},
+	"Zaman":     {object.TIME_OBJ},
+	"Fonksiyon": {object.FUNCTION_OBJ, object.BUILTIN_OBJ},
+	"Null":      {object.NULL_OBJ},
+}
+
+func evalMatchExpression(me *ast.MatchExpression, env *object.Environment) object.Object {
+	subject := Eval(me.Subject, env)
+	if isError(subject) {
+		return subject
+	}
+
+	for _, arm := range me.Arms {
+		// kalıpta bağlanan adlar yalnızca o kolun içinde görünür
+		armEnv := object.NewBlockEnvironment(env)
+
+		matched, err := matchPattern(arm.Pattern, subject, armEnv)
+		if err != nil {
+			return err
+		}
+
+		if !matched {
+			continue
+		}
+
+		if arm.Guard != nil {
+			guard := Eval(arm.Guard, armEnv)
+			if isError(guard) {
+				return guard
+			}
+
+			if !isTruthy(guard) {
+				continue
+			}
+		}
+
+		result := Eval(arm.Body, armEnv)
+		if result == nil {
+			return NULL
+		}
+
+		return result
+	}
+
+	return newError(me.Token, "eşle: %s hiçbir kalıpla eşleşmedi", subject.Inspect())
+}
+
+// matchPattern değerin kalıba uyup uymadığına bakar, uyarsa kalıptaki adları env'e bağlar
+func matchPattern(pattern ast.Pattern, value object.Object, env *object.Environment) (bool, object.Object) {
+	switch p := pattern.(type) {
+	case *ast.WildcardPattern:
+		return true, nil
+	case *ast.BindingPattern:
+		env.Set(p.Name, value)
+		return true, nil
+	case *ast.LiteralPattern:
+		expected := Eval(p.Value, env)
+		if isError(expected) {
+			return false, expected
+		}
+
+		return patternEquals(p.Token, expected, value), nil
+	case *ast.RangePattern:
+		from := Eval(p.From, env)
+		if isError(from) {
+			return false, from
+		}
+
+		to := Eval(p.To, env)
+		if isError(to) {
+			return false, to
+		}
+
+		return lessOrEqual(p.Token, from, value) && lessOrEqual(p.Token, value, to), nil
+	case *ast.TypePattern:
+		matched, err := isOfType(p, value, env)
+		if err != nil || !matched {
+			return false, err
+		}
+
+		if p.Binding != "" {
+			env.Set(p.Binding, value)
+		}
+
+		if p.Inner != nil {
+			return matchPattern(p.Inner, value, env)
+		}
+
+		return true, nil
+	case *ast.DefaultPattern:
+		if value.Type() == object.NULL_OBJ {
+			value = Eval(p.Default, env)
+			if isError(value) {
+				return false, value
+			}
+		}
+
+		return matchPattern(p.Pattern, value, env)
+	case *ast.ArrayPattern:
+		return matchArrayPattern(p, value, env)
+	case *ast.HashPattern:
+		return matchHashPattern(p, value, env)
+	case *ast.AlternativePattern:
+		for _, option := range p.Options {
+			matched, err := matchPattern(option, value, env)
+			if err != nil || matched {
+				return matched, err
+			}
+		}
+
+		return false, nil
+	}
+
+	return false, nil
+}
+
+func matchArrayPattern(p *ast.ArrayPattern, value object.Object, env *object.Environment) (bool, object.Object) {
+	array, ok := value.(*object.Array)
+	if !ok {
+		return false, nil
+	}
+
+	if len(array.Elements) < len(p.Elements) || (!p.HasRest && len(array.Elements) != len(p.Elements)) {
+		return false, nil
+	}
+
+	for i, element := range p.Elements {
+		matched, err := matchPattern(element, array.Elements[i], env)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+
+	if p.Rest != "" {
+		rest := make([]object.Object, len(array.Elements)-len(p.Elements))
+		copy(rest, array.Elements[len(p.Elements):])
+		env.Set(p.Rest, &object.Array{Token: p.Token, Elements: rest})
+	}
+
+	return true, nil
+}
+
+// harita kalıpları kullanıcı tiplerinin örneklerinde alanlarla eşleşir
+func matchHashPattern(p *ast.HashPattern, value object.Object, env *object.Environment) (bool, object.Object) {
+	var hash *object.Hash
+
+	switch v := value.(type) {
+	case *object.Hash:
+		hash = v
+	case *object.Instance:
+		hash = v.Fields
+	default:
+		return false, nil
+	}
+
+	for i, key := range p.Keys {
+		var item object.Object = NULL
+
+		pair, ok := hash.GetPair(key)
+		if ok {
+			item = pair.Value
+		} else if _, hasDefault := p.Values[i].(*ast.DefaultPattern); !hasDefault {
+			return false, nil
+		}
+
+		matched, err := matchPattern(p.Values[i], item, env)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+
+	if p.Rest != "" {
+		env.Set(p.Rest, remainingPairs(p, hash))
+	}
+
+	return true, nil
+}
+
+func isOfType(p *ast.TypePattern, value object.Object, env *object.Environment) (bool, object.Object) {
+	if types, ok := patternTypes[p.TypeName]; ok {
+		if p.TypeName == "Tamsayı" {
+			n, ok := value.(*object.Number)
+			return ok && n.Integer, nil
+		}
+
+		for _, t := range types {
+			if value.Type() == t {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	definition, ok := env.Get(p.TypeName)
+	if !ok {
+		return false, newError(p.Token, "eşle: bilinmeyen tip %s", p.TypeName)
+	}
+
+	td, ok := definition.(*object.TypeDef)
+	if !ok {
+		return false, newError(p.Token, "eşle: %s bir tip değil (tip %s)", p.TypeName, definition.Type())
+	}
+
+	instance, ok := value.(*object.Instance)
+	return ok && instance.Of == td, nil
+}
+
+// patternEquals sayıları değerce, diğer sabitleri anahtarlarıyla karşılaştırır
+func patternEquals(tok token.Token, expected, value object.Object) bool {
+	switch {
+	case expected.Type() == object.NUMBER_OBJ && value.Type() == object.NUMBER_OBJ:
+		return compareNumbers(expected.(*object.Number), value.(*object.Number)) == 0
+	case isDecimalOperand(expected, value):
+		return evalDecimalInfixExpression(tok, "==", expected, value) == TRUE
+	case expected.Type() == value.Type():
+		if e, ok := expected.(object.Hashable); ok {
+			return e.HashKey() == value.(object.Hashable).HashKey()
+		}
+	}
+
+	return expected == value
+}
+
+// aralık kalıpları sayılar, ondalıklar ve yazılar için geçerlidir
+func lessOrEqual(tok token.Token, a, b object.Object) bool {
+	switch {
+	case a.Type() == object.NUMBER_OBJ && b.Type() == object.NUMBER_OBJ:
+		return compareNumbers(a.(*object.Number), b.(*object.Number)) <= 0
+	case isDecimalOperand(a, b):
+		return evalDecimalInfixExpression(tok, "<=", a, b) == TRUE
+	case a.Type() == object.STRING_OBJ && b.Type() == object.STRING_OBJ:
+		return a.(*object.String).Value <= b.(*object.String).Value
+	}
+
+	return false
+}
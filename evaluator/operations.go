@@ -0,0 +1,71 @@
+package evaluator
+
+import (
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+// Bu dosyadaki fonksiyonlar yorumlayıcının işlemlerini AST'den bağımsız olarak
+// dışa açar; bayt kodu sanal makinesi (vm) değerler üzerinde aynı anlamı
+// bunlarla paylaşır.
+
+func NewError(tok token.Token, format string, a ...interface{}) *object.Error {
+	return newError(tok, format, a...)
+}
+
+// Lookup adı ortamda, yoksa yerleşik fonksiyonlarda arar
+func Lookup(tok token.Token, name string, env *object.Environment) object.Object {
+	return lookupName(tok, name, env)
+}
+
+func IsTruthy(obj object.Object) bool {
+	return isTruthy(obj)
+}
+
+func Prefix(tok token.Token, operator string, right object.Object) object.Object {
+	return evalPrefixExpression(tok, operator, right)
+}
+
+func Infix(tok token.Token, operator string, left, right object.Object) object.Object {
+	return evalInfixOperation(tok, operator, left, right)
+}
+
+// Index dizin ve aralık erişimidir; aralık dışında end NULL olmalıdır
+func Index(tok token.Token, left, index, end object.Object, isRange bool) object.Object {
+	return evalIndexOperation(tok, left, index, end, isRange)
+}
+
+func SetIndex(tok token.Token, left, index, value object.Object) object.Object {
+	return assignIndex(tok, left, index, value)
+}
+
+func Property(tok token.Token, o object.Object, property string, optional bool) object.Object {
+	return evalPropertyOperation(tok, o, property, optional)
+}
+
+func SetProperty(tok token.Token, o object.Object, property string, value object.Object) object.Object {
+	return assignProperty(tok, o, property, value)
+}
+
+func Call(tok token.Token, fn object.Object, env *object.Environment, args []object.Object, keywords []object.KeywordArgument) object.Object {
+	return callFunction(tok, fn, env, args, keywords)
+}
+
+func CallMethod(tok token.Token, o object.Object, method string, optional bool, env *object.Environment, args []object.Object, keywords []object.KeywordArgument) object.Object {
+	return applyMethod(tok, o, method, optional, env, args, keywords)
+}
+
+// Command değişkenleri yerine konmuş bir komutu çalıştırır
+func Command(tok token.Token, cmd string) object.Object {
+	return runCommand(tok, cmd)
+}
+
+// ErrorToHash yakala bloğuna verilen hata haritasını oluşturur
+func ErrorToHash(e *object.Error) *object.Hash {
+	return errorToHash(e)
+}
+
+// Iterate için ... içinde döngüsünün değeri nasıl dolaştığını döner
+func Iterate(tok token.Token, iterable object.Object, env *object.Environment) (next func() (object.Object, object.Object), reset func(), err object.Object) {
+	return iterate(tok, iterable, env)
+}
@@ -0,0 +1,210 @@
+package evaluator
+
+import (
+	"container/list"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+// derlenmiş desenler en son kullanılma sırasıyla tutulur; önbellek dolunca en
+// uzun süredir kullanılmayan desen atılır
+const regexCacheSize = 256
+
+var regexCache = map[string]*list.Element{}
+var regexCacheOrder = list.New()
+var regexCacheLock sync.Mutex
+
+type cachedRegex struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+func compileRegex(tok token.Token, pattern string) (*regexp.Regexp, object.Object) {
+	regexCacheLock.Lock()
+	defer regexCacheLock.Unlock()
+
+	if e, ok := regexCache[pattern]; ok {
+		regexCacheOrder.MoveToFront(e)
+		return e.Value.(*cachedRegex).re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, newError(tok, "geçersiz düzenli ifade %s: %s", pattern, err.Error())
+	}
+
+	regexCache[pattern] = regexCacheOrder.PushFront(&cachedRegex{pattern: pattern, re: re})
+
+	if regexCacheOrder.Len() > regexCacheSize {
+		oldest := regexCacheOrder.Back()
+		regexCacheOrder.Remove(oldest)
+		delete(regexCache, oldest.Value.(*cachedRegex).pattern)
+	}
+
+	return re, nil
+}
+
+// eşleşmeyi {"metin", "gruplar", "adlar", "konum"} biçiminde bir haritaya çevirir
+func regexMatchToHash(tok token.Token, re *regexp.Regexp, s string, loc []int) *object.Hash {
+	groups := []object.Object{}
+	names := &object.Hash{Token: tok}
+
+	for i := 1; i < len(loc)/2; i++ {
+		var group object.Object = NULL
+
+		if loc[2*i] >= 0 {
+			group = &object.String{Token: tok, Value: s[loc[2*i]:loc[2*i+1]]}
+		}
+
+		groups = append(groups, group)
+
+		if name := re.SubexpNames()[i]; name != "" {
+			names.Set(&object.String{Token: tok, Value: name}, group)
+		}
+	}
+
+	return hashFromEntries(tok, []hashEntry{
+		{"metin", &object.String{Token: tok, Value: s[loc[0]:loc[1]]}},
+		{"gruplar", &object.Array{Token: tok, Elements: groups}},
+		{"adlar", names},
+		{"konum", object.NewInteger(tok, int64(loc[0]))},
+	})
+}
+
+func regexTestFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "eşleşir", args, 2, [][]string{{object.STRING_OBJ}, {object.STRING_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	re, err := compileRegex(tok, args[1].(*object.String).Value)
+	if err != nil {
+		return err
+	}
+
+	return nativeBoolToBooleanObject(re.MatchString(args[0].(*object.String).Value))
+}
+
+func regexMatchFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "eşleşme", args, 2, [][]string{{object.STRING_OBJ}, {object.STRING_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	re, err := compileRegex(tok, args[1].(*object.String).Value)
+	if err != nil {
+		return err
+	}
+
+	s := args[0].(*object.String).Value
+	loc := re.FindStringSubmatchIndex(s)
+
+	if loc == nil {
+		return NULL
+	}
+
+	return regexMatchToHash(tok, re, s, loc)
+}
+
+func regexMatchAllFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "eşleşmeler", args, [][][]string{
+		{{object.STRING_OBJ}, {object.STRING_OBJ}, {object.NUMBER_OBJ}},
+		{{object.STRING_OBJ}, {object.STRING_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	re, err := compileRegex(tok, args[1].(*object.String).Value)
+	if err != nil {
+		return err
+	}
+
+	n := -1
+	if spec == 0 {
+		n = args[2].(*object.Number).Int()
+	}
+
+	s := args[0].(*object.String).Value
+	matches := []object.Object{}
+
+	for _, loc := range re.FindAllStringSubmatchIndex(s, n) {
+		matches = append(matches, regexMatchToHash(tok, re, s, loc))
+	}
+
+	return &object.Array{Token: tok, Elements: matches}
+}
+
+// yerine bir metinse \$1 / \${ad} geri başvurularıyla, bir fonksiyonsa
+// her eşleşmenin haritasıyla çağrılıp dönen değerle değiştirilir. Yazılardaki
+// $ad değişken olarak yerleştirildiğinden geri başvurularda $ kaçırılmalıdır:
+// desen_değiştir("ab", "(a)(b)", "\$2\$1") "ba" döner.
+func regexReplaceFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "desen_değiştir", args, 3, [][]string{{object.STRING_OBJ}, {object.STRING_OBJ}, {object.STRING_OBJ, object.FUNCTION_OBJ, object.BUILTIN_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	re, err := compileRegex(tok, args[1].(*object.String).Value)
+	if err != nil {
+		return err
+	}
+
+	s := args[0].(*object.String).Value
+
+	if replacement, ok := args[2].(*object.String); ok {
+		return &object.String{Token: tok, Value: re.ReplaceAllString(s, replacement.Value)}
+	}
+
+	var out strings.Builder
+	last := 0
+
+	for _, loc := range re.FindAllStringSubmatchIndex(s, -1) {
+		evaluated := applyFunction(tok, args[2], env, []object.Object{regexMatchToHash(tok, re, s, loc)})
+		if isError(evaluated) {
+			return evaluated
+		}
+
+		out.WriteString(s[last:loc[0]])
+		out.WriteString(evaluated.Inspect())
+		last = loc[1]
+	}
+
+	out.WriteString(s[last:])
+	return &object.String{Token: tok, Value: out.String()}
+}
+
+func regexSplitFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "desen_ayır", args, [][][]string{
+		{{object.STRING_OBJ}, {object.STRING_OBJ}, {object.NUMBER_OBJ}},
+		{{object.STRING_OBJ}, {object.STRING_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	re, err := compileRegex(tok, args[1].(*object.String).Value)
+	if err != nil {
+		return err
+	}
+
+	n := -1
+	if spec == 0 {
+		n = args[2].(*object.Number).Int()
+	}
+
+	parts := re.Split(args[0].(*object.String).Value, n)
+	elements := make([]object.Object, len(parts))
+
+	for k, v := range parts {
+		elements[k] = &object.String{Token: tok, Value: v}
+	}
+
+	return &object.Array{Token: tok, Elements: elements}
+}
@@ -0,0 +1,79 @@
+package evaluator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ankalang/anka/token"
+)
+
+func TestRegex(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{`eşleşir("abc123", "[0-9]+")`, "true"},
+		{`eşleşir("abc", "^[0-9]+$")`, "false"},
+		{`eşleşme("tarih: 2024-05-01", "(\d+)-(\d+)")`, `{"metin": "2024-05", "gruplar": ["2024", "05"], "adlar": {}, "konum": 7}`},
+		{`eşleşme("abc", "\d")`, "null"},
+		{`eşleşme("ali 30", "(?P<ad>[a-z]+) (?P<yas>\d+)").adlar.yas`, "30"},
+		{`eşleşme("ab", "(a)(x)?b").gruplar`, `["a", null]`},
+		{`eşleşmeler("a1b22c333", "\d+").haritala(f(m) { m.metin })`, `["1", "22", "333"]`},
+		{`eşleşmeler("a1b22c333", "\d+", 2).uzunluk()`, "2"},
+		{`eşleşmeler("abc", "\d")`, "[]"},
+		{`desen_ayır("a, b,c", ",\s*")`, `["a", "b", "c"]`},
+		{`desen_ayır("a,b,c", ",", 2)`, `["a", "b,c"]`},
+	}
+
+	for _, tt := range tests {
+		expect(t, tt.code, tt.want)
+	}
+
+	errors := []struct {
+		code    string
+		message string
+	}{
+		{`eşleşir("a", "(")`, "geçersiz düzenli ifade (: error parsing regexp: missing closing )"},
+		{`eşleşme("a", "[")`, "geçersiz düzenli ifade ["},
+		{`eşleşmeler("a", "a**")`, "geçersiz düzenli ifade a**"},
+		{`desen_değiştir("a", "*", "b")`, "geçersiz düzenli ifade *"},
+		{`desen_ayır("a", "(?P<>a)")`, "geçersiz düzenli ifade"},
+		{`eşleşir(1, "a")`, "0 argümanı eşleşir(...) için destekli değildir"},
+		{`desen_değiştir("a", "a", 1)`, "2 argümanı desen_değiştir(...) için destekli değildir"},
+		{`desen_değiştir("a", "a", f(m) { olmayan })`, "Bulunamadı: olmayan"},
+	}
+
+	for _, tt := range errors {
+		expectError(t, tt.code, tt.message)
+	}
+}
+
+func TestRegexReplace(t *testing.T) {
+	expect(t, `desen_değiştir("ab", "(a)(b)", "\$2\$1")`, "ba")
+	expect(t, `desen_değiştir("ab", "(?P<x>a)", "<\${x}>")`, "<a>b")
+	expect(t, `desen_değiştir("a1b22", "[0-9]+", f(m) { uzunluk(m.metin) })`, "a1b2")
+}
+
+func TestRegexCacheIsBounded(t *testing.T) {
+	first := "^ilk$"
+	if _, err := compileRegex(token.Token{}, first); err != nil {
+		t.Fatal(err.Inspect())
+	}
+
+	for i := 0; i < regexCacheSize*2; i++ {
+		// en sık kullanılan desen önbellekte kalmalı
+		compileRegex(token.Token{}, first)
+
+		if _, err := compileRegex(token.Token{}, fmt.Sprintf("x%d", i)); err != nil {
+			t.Fatal(err.Inspect())
+		}
+	}
+
+	if len(regexCache) > regexCacheSize || regexCacheOrder.Len() != len(regexCache) {
+		t.Errorf("önbellekte %d desen var, en fazla %d olmalı", len(regexCache), regexCacheSize)
+	}
+
+	if _, ok := regexCache[first]; !ok {
+		t.Errorf("sık kullanılan desen önbellekten atıldı")
+	}
+}
@@ -0,0 +1,135 @@
+package evaluator
+
+import (
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+func addToSet(tok token.Token, set *object.Set, elements []object.Object) object.Object {
+	for _, e := range elements {
+		if _, ok := e.(object.Hashable); !ok {
+			return newError(tok, "Küme elemanı olarak %s kullanılamaz", e.Type())
+		}
+
+		set.Add(e)
+	}
+
+	return set
+}
+
+// küme() boş, küme([1, 2, 2]) dizideki eşsiz elemanlarla bir küme oluşturur
+func setFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, _ := validateVarArgs(tok, "küme", args, [][][]string{
+		{{object.ARRAY_OBJ, object.SET_OBJ}},
+		{},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	set := object.NewSet(tok)
+	if len(args) == 0 {
+		return set
+	}
+
+	switch arg := args[0].(type) {
+	case *object.Array:
+		return addToSet(tok, set, arg.Elements)
+	case *object.Set:
+		return addToSet(tok, set, arg.Ordered())
+	}
+
+	return set
+}
+
+// kes, birleştir, fark ve s_fark ilk argüman küme olduğunda buraya düşer;
+// ikinci argüman dizi ise önce kümeye çevrilir
+func setOperation(tok token.Token, operator string, left *object.Set, right object.Object) object.Object {
+	if array, ok := right.(*object.Array); ok {
+		converted := addToSet(tok, object.NewSet(tok), array.Elements)
+		if isError(converted) {
+			return converted
+		}
+
+		right = converted
+	}
+
+	r, ok := right.(*object.Set)
+	if !ok {
+		return newError(tok, "küme işlemi için ikinci argüman SET ya da ARRAY olmalı, bulunan %s", right.Type())
+	}
+
+	return evalSetInfixExpression(tok, operator, left, r)
+}
+
+func evalSetInfixExpression(tok token.Token, operator string, left, right object.Object) object.Object {
+	l := left.(*object.Set)
+	r := right.(*object.Set)
+
+	switch operator {
+	case "|":
+		result := object.NewSet(tok)
+
+		for _, e := range l.Ordered() {
+			result.Add(e)
+		}
+
+		for _, e := range r.Ordered() {
+			result.Add(e)
+		}
+
+		return result
+	case "&":
+		return filterSet(tok, l, func(e object.Object) bool { return r.Has(e) })
+	case "-":
+		return filterSet(tok, l, func(e object.Object) bool { return !r.Has(e) })
+	case "^":
+		result := filterSet(tok, l, func(e object.Object) bool { return !r.Has(e) })
+
+		for _, e := range r.Ordered() {
+			if !l.Has(e) {
+				result.Add(e)
+			}
+		}
+
+		return result
+	case "==":
+		return nativeBoolToBooleanObject(len(l.Elements) == len(r.Elements) && isSubset(l, r))
+	case "!=":
+		return nativeBoolToBooleanObject(len(l.Elements) != len(r.Elements) || !isSubset(l, r))
+	case "<=":
+		return nativeBoolToBooleanObject(isSubset(l, r))
+	case "<":
+		return nativeBoolToBooleanObject(len(l.Elements) < len(r.Elements) && isSubset(l, r))
+	case ">=":
+		return nativeBoolToBooleanObject(isSubset(r, l))
+	case ">":
+		return nativeBoolToBooleanObject(len(r.Elements) < len(l.Elements) && isSubset(r, l))
+	}
+
+	return newError(tok, "Bilinmeyen operatör: %s %s %s", left.Type(), operator, right.Type())
+}
+
+func filterSet(tok token.Token, set *object.Set, keep func(object.Object) bool) *object.Set {
+	result := object.NewSet(tok)
+
+	for _, e := range set.Ordered() {
+		if keep(e) {
+			result.Add(e)
+		}
+	}
+
+	return result
+}
+
+// a'nın tüm elemanları b'de var mı
+func isSubset(a, b *object.Set) bool {
+	for k := range a.Elements {
+		if _, ok := b.Elements[k]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,54 @@
+package evaluator
+
+import "testing"
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{`küme([3, 1, 3, 2])`, "küme([3, 1, 2])"},
+		{`küme()`, "küme([])"},
+		{`küme([1, 2]).uzunluk()`, "2"},
+		{`küme(küme([1, 1]))`, "küme([1])"},
+		{`küme([1, 1.0, "1"])`, `küme([1, "1"])`},
+		{`küme([1, 2, 3]) & küme([2, 3, 4])`, "küme([2, 3])"},
+		{`küme([1, 2]) | küme([2, 3])`, "küme([1, 2, 3])"},
+		{`küme([1, 2, 3]) - küme([2])`, "küme([1, 3])"},
+		{`küme([1, 2]) ^ küme([2, 3])`, "küme([1, 3])"},
+		{`küme([1, 2]) == küme([2, 1])`, "true"},
+		{`küme([1]) < küme([1, 2])`, "true"},
+		{`küme([1, 2]) >= küme([1, 2])`, "true"},
+		{`2 in küme([1, 2])`, "true"},
+		{`"x" in küme([1, 2])`, "false"},
+		{`küme([1, 2, 3]).kes([2, 3, 9])`, "küme([2, 3])"},
+		{`küme([1]).birleştir([2])`, "küme([1, 2])"},
+		{`küme([1, 2]).fark([1])`, "küme([2])"},
+		{`küme([1, 2]).s_fark([2, 3])`, "küme([1, 3])"},
+		{"s = küme([1]); s.it(2); s", "küme([1, 2])"},
+		{"s = küme([1, 2]); s.çıkar(1); s", "küme([2])"},
+		{`küme([1]).çıkar(2)`, "null"},
+		{`küme([1]).değerler()`, "[1]"},
+	}
+
+	for _, tt := range tests {
+		expect(t, tt.code, tt.want)
+	}
+
+	errors := []struct {
+		code    string
+		message string
+	}{
+		{`küme([[1]])`, "Küme elemanı olarak ARRAY kullanılamaz"},
+		{`küme([1, {"a": 1}])`, "Küme elemanı olarak HASH kullanılamaz"},
+		{`küme([1]).it([2])`, "Küme elemanı olarak ARRAY kullanılamaz"},
+		{`küme([1]).birleştir([[1]])`, "Küme elemanı olarak ARRAY kullanılamaz"},
+		{`küme([1]).kes(3)`, "küme işlemi için ikinci argüman SET ya da ARRAY olmalı, bulunan NUMBER"},
+		{`küme([1]) + küme([2])`, "Bilinmeyen operatör: SET + SET"},
+		{`küme(1)`, "yanlış sayıda argüman"},
+	}
+
+	for _, tt := range errors {
+		expectError(t, tt.code, tt.message)
+	}
+}
@@ -0,0 +1,42 @@
+package evaluator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ankalang/anka/object"
+)
+
+func TestSourceParseError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "anka")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "bozuk.ank")
+	if err := ioutil.WriteFile(file, []byte("x = 1\ny = (\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, _ := run(t, `kaynak("`+file+`")`)
+	e, ok := result.(*object.Error)
+	if !ok {
+		t.Fatalf("hata bekleniyordu, bulunan %s", inspect(result))
+	}
+
+	if e.File != file || e.Line != 3 || e.Column != 1 {
+		t.Errorf("hatanın konumu %s:%d:%d, istenen %s:3:1", e.File, e.Line, e.Column, file)
+	}
+
+	if !strings.HasPrefix(e.Text, "ayrıştırıcı hatası: ") || strings.Contains(e.Text, "\033") {
+		t.Errorf("hata metni: %q", e.Text)
+	}
+
+	if len(e.Stack) != 1 || e.Stack[0].Function != "modül "+file {
+		t.Errorf("çağrı yığını: %+v", e.Stack)
+	}
+}
@@ -52,6 +52,15 @@ func stdlib_util_index_ank() ([]byte, error) {
 	)
 }
 
+var _stdlib_http_index_ank = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x54\xc1\x6e\xe3\x36\x10\xbd\xeb\x2b\xa6\xcc\xc5\x42\x04\xa5\x05\xf6\xa4\x85\x81\x1e\x36\x68\x0f\x05\xba\x40\xd3\x02\x0b\xc3\x58\x4c\x42\x3a\xcb\x8a\xa2\x02\x4a\x5c\x80\xf1\xea\x5b\x7c\xcc\xb5\xba\xf8\x94\x9b\x98\xff\x2a\x48\x4a\xaa\x64\x3b\x06\x9a\x85\x2f\xd6\x70\xe6\xbd\x79\x6f\x86\xbc\x80\x5f\x6f\x6e\x3e\x02\xaf\x6a\x56\xdc\xf1\x8a\x47\x5f\xea\xfa\x01\x96\xb0\x6d\xa2\xe8\x02\xfe\x62\x8a\x0b\x26\xc1\x74\x7b\x59\xb3\x42\x30\x9f\x98\xc3\x7d\xb7\x97\x94\x29\xae\xde\x43\xc5\xba\x27\x26\x59\x2e\x98\xca\xe0\x16\x5f\x76\xc2\xb6\xb9\x40\x95\x40\x55\xaa\x7b\x9d\xc0\xdf\x55\x29\x13\x57\xf1\x95\xb2\x04\x1e\xb1\x40\xf9\x19\x5f\x76\xb6\x2d\x6c\x9b\x00\x2d\x2b\x83\x9e\x34\x0d\xd0\x4b\xd8\x2c\x7a\xba\x04\xb4\x12\xc9\x94\xc1\x37\x16\xc3\x36\x02\x00\xa0\xdd\x5e\x82\xab\xfc\xec\x2b\x5f\xaf\x8a\xa3\x26\x0a\x14\x28\x3c\xfe\xff\x82\x25\xbf\x5c\xdf\x90\x73\xa0\xbd\x19\x6f\x40\xfe\xf8\xfb\x1f\xe7\xa1\xf3\xd2\xbc\x05\xf6\xcf\xf3\xa8\x15\x7f\x8b\x0d\x1f\xae\x7f\xbb\xbe\xb9\x7e\x0d\xf8\x02\x3e\xa1\xb4\x6d\xdd\x0f\xba\xe2\x92\xc3\x2d\x13\x82\xd9\x1d\x03\x14\x05\x52\x94\x40\x4b\xbb\x53\x3a\xfc\xab\x0c\x1a\x04\x83\x8f\xa8\xfa\xf1\x4b\xca\x95\x1f\xbf\xef\xcb\x67\x7c\xf7\x94\xe0\x12\xb6\xc4\x43\x91\x2c\xec\x5a\xd3\xb7\xeb\xd7\xbe\xd2\x52\xdf\xe9\x4a\xbf\x07\xfe\xb2\x13\xcc\xf0\x3b\xee\x8a\x7e\x0e\xf1\xd4\x94\x62\x00\x26\x57\xb9\x16\xc2\x49\xbc\xb3\xad\x40\x75\x95\x71\x4a\x62\xa0\x2c\x2f\x15\xd6\xdd\x5e\x75\xcf\x46\x30\xc8\xd1\x50\x46\xb9\xe0\xbd\xa8\x00\xe4\x55\x0d\x9d\x8f\xa1\x6d\x33\xf9\x76\x5c\x02\x9d\xc6\xd5\x3a\x3a\x88\xcf\xef\x84\x29\xc5\x00\x35\x1a\xb1\x59\x6c\xe4\x34\x78\x84\x9b\xf2\x7a\xb1\x25\x3d\x06\xc9\x86\x0b\x9d\xde\x76\xcf\xa6\x7b\xce\x17\x71\x02\xe4\x01\x55\xf7\x84\x02\x95\x3b\x2f\x45\x8a\xc6\xb6\x6a\x41\xae\x88\x3b\xdc\x48\x92\xc1\x46\x36\xf1\x8c\x23\x90\xcb\x31\x16\x14\x35\x41\xc0\x05\xd8\x7f\xaa\xda\x2f\x80\x36\x28\x81\x8b\xdc\xe1\x6a\x70\x2c\xb6\x7d\xd9\xd5\xb6\x55\xb6\x55\x09\x90\x0c\x29\x81\x91\xdf\xb6\xe1\x99\x49\x1f\x50\x61\xc1\x6a\xc5\xdc\x50\x78\xf7\xc4\x25\x73\x1b\x63\x5b\x61\x5b\x35\x35\xc9\x4f\xcf\xdb\xe4\x0b\xa7\x56\x8c\xa8\xb0\xec\x51\xe7\xda\xa2\x31\xd3\x89\xb9\xef\x9e\xdd\x0c\xdd\x22\x73\x39\xb7\xf0\xc0\x5e\xa7\x4b\xf5\xb9\x69\x6f\x27\xfc\x30\x72\xf4\x81\x6f\xdf\x86\x94\xb1\x8f\x54\x3f\x6a\x29\x74\xbe\x88\x5d\xfa\xa9\xf0\x9c\xc8\xfd\x28\xfb\x8a\xc5\x2c\xda\x44\xb3\xcf\x99\x53\xe3\x6a\x0d\x3f\x6d\xa8\x86\x25\x7c\xf0\xd7\x6f\x5e\x38\x88\xe6\x49\xdf\x0a\x70\x79\xd4\xf2\x89\x8e\x82\xfc\x90\x91\x76\x7b\xe9\x5e\x88\x8c\x9c\xea\xfd\xb0\xbd\x55\x28\x5a\xfd\x94\xad\xd7\x30\x31\x60\xc5\xd7\x47\xb5\x0d\x98\x32\xaf\x70\xc6\x36\x73\x6d\xc5\xd7\xaf\x50\xf6\x9a\x3f\xa1\xf4\xbb\x76\x32\x87\x6a\x75\x14\x6f\xce\xd9\x1c\xfa\xf0\xd0\xc7\xac\x27\x76\x76\x39\x53\x7e\x54\xe1\xcc\x1f\xcc\xde\xc8\x85\x07\x88\xa3\xd3\xdd\x4c\x3a\xf1\x65\x5b\x42\xb5\xd2\x05\xc9\xe0\xdd\x8f\xef\x12\x20\xe1\xdd\x25\x19\x90\x5b\x2d\xb4\xc4\x02\xa9\x6d\x33\x20\x70\x79\xb0\x92\x97\x40\xa6\xd1\x52\xcc\x2e\x6d\xbf\xf2\x94\xcb\xfe\x42\x21\x55\xac\x9a\xce\xf5\xbf\x97\x37\xe4\x86\x8c\x64\x76\x15\xe3\x29\xa4\x2f\x08\xa7\xee\xe9\xa5\xdd\x5e\xc2\x97\xba\x7e\x88\xfe\x1d\x00\x00\x2f\xea\xfb\x7c\x08\x00\x00")
+
+func stdlib_http_index_ank() ([]byte, error) {
+	return bindata_read(
+		_stdlib_http_index_ank,
+		"stdlib/http/index.ank",
+	)
+}
+
 
 
 
@@ -77,6 +86,7 @@ var _bindata = map[string]func() ([]byte, error){
 	"stdlib/cli/index.ank": stdlib_cli_index_ank,
 	"stdlib/runtime/index.ank": stdlib_runtime_index_ank,
 	"stdlib/util/index.ank": stdlib_util_index_ank,
+	"stdlib/http/index.ank": stdlib_http_index_ank,
 }
 
 
@@ -131,5 +141,9 @@ var _bintree = &_bintree_t{nil, map[string]*_bintree_t{
 			"index.ank": &_bintree_t{stdlib_util_index_ank, map[string]*_bintree_t{
 			}},
 		}},
+		"http": &_bintree_t{nil, map[string]*_bintree_t{
+			"index.ank": &_bintree_t{stdlib_http_index_ank, map[string]*_bintree_t{
+			}},
+		}},
 	}},
 }}
@@ -0,0 +1,379 @@
+package evaluator
+
+import (
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+var turkishMonths = []string{"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran", "Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık"}
+var turkishShortMonths = []string{"Oca", "Şub", "Mar", "Nis", "May", "Haz", "Tem", "Ağu", "Eyl", "Eki", "Kas", "Ara"}
+var turkishDays = []string{"Pazar", "Pazartesi", "Salı", "Çarşamba", "Perşembe", "Cuma", "Cumartesi"}
+var turkishShortDays = []string{"Paz", "Pzt", "Sal", "Çar", "Per", "Cum", "Cmt"}
+
+var namedLayouts = map[string]string{
+	"iso":     time.RFC3339Nano,
+	"rfc3339": time.RFC3339,
+	"rfc1123": time.RFC1123,
+}
+
+// düzen verilmediğinde tarih() bu düzenleri sırayla dener
+var defaultLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+var strftimeDirectives = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'e': "_2",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'L': "000",
+	'f': "000000",
+	'p': "PM",
+	'j': "002",
+	'z': "-0700",
+	'Z': "MST",
+	'b': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'%': "%",
+}
+
+// düzen "%" içeriyorsa strftime biçiminde kabul edilip Go düzenine çevrilir
+func toGoLayout(layout string) string {
+	if named, ok := namedLayouts[strings.ToLower(layout)]; ok {
+		return named
+	}
+
+	if !strings.Contains(layout, "%") {
+		return layout
+	}
+
+	var out strings.Builder
+
+	for i := 0; i < len(layout); i++ {
+		if layout[i] == '%' && i+1 < len(layout) {
+			if directive, ok := strftimeDirectives[layout[i+1]]; ok {
+				out.WriteString(directive)
+				i++
+				continue
+			}
+		}
+
+		out.WriteByte(layout[i])
+	}
+
+	return out.String()
+}
+
+func formatTime(t time.Time, layout string, lang string) string {
+	layout = toGoLayout(layout)
+
+	if lang == "tr" {
+		// uzun adlar kısaltmalardan önce değiştirilmeli: "January" içinde "Jan" da geçiyor
+		layout = strings.Replace(layout, "January", turkishMonths[t.Month()-1], -1)
+		layout = strings.Replace(layout, "Jan", turkishShortMonths[t.Month()-1], -1)
+		layout = strings.Replace(layout, "Monday", turkishDays[t.Weekday()], -1)
+		layout = strings.Replace(layout, "Mon", turkishShortDays[t.Weekday()], -1)
+	}
+
+	return t.Format(layout)
+}
+
+// Türkçe ay ve gün adlarını Go'nun ayrıştırabileceği İngilizce adlara çevirir
+func translateTurkishNames(s string) string {
+	for _, names := range [][2][]string{
+		{turkishMonths, monthNames(time.Month.String)},
+		{turkishDays, dayNames(time.Weekday.String)},
+		{turkishShortMonths, monthNames(func(m time.Month) string { return m.String()[:3] })},
+		{turkishShortDays, dayNames(func(d time.Weekday) string { return d.String()[:3] })},
+	} {
+		// "Pazartesi" ve "Cumartesi", "Pazar" ve "Cuma"dan önce değiştirilmeli
+		for i := len(names[0]) - 1; i >= 0; i-- {
+			s = strings.Replace(s, names[0][i], names[1][i], -1)
+		}
+	}
+
+	return s
+}
+
+func monthNames(name func(time.Month) string) []string {
+	names := []string{}
+	for m := time.January; m <= time.December; m++ {
+		names = append(names, name(m))
+	}
+	return names
+}
+
+func dayNames(name func(time.Weekday) string) []string {
+	names := []string{}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		names = append(names, name(d))
+	}
+	return names
+}
+
+func loadLocation(tok token.Token, name string) (*time.Location, object.Object) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, newError(tok, "bilinmeyen saat dilimi: %s", name)
+	}
+
+	return loc, nil
+}
+
+// süreler milisaniye cinsinden sayılardır, "1h30m" gibi metinler de kabul edilir
+func durationArg(tok token.Token, arg object.Object) (time.Duration, object.Object) {
+	switch d := arg.(type) {
+	case *object.Number:
+		return time.Duration(d.Value * float64(time.Millisecond)), nil
+	case *object.String:
+		parsed, err := time.ParseDuration(d.Value)
+		if err != nil {
+			return 0, newError(tok, "geçersiz süre: %s", d.Value)
+		}
+
+		return parsed, nil
+	}
+
+	return 0, newError(tok, "süre bir sayı ya da metin olmalı, bulunan %s", arg.Type())
+}
+
+func nowFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	return &object.Time{Token: tok, Value: time.Now()}
+}
+
+// tarih("2024-01-15"), tarih("15 Ocak 2024", "%d %B %Y", "Europe/Istanbul"),
+// tarih(1700000000000) (unix ms) ya da tarih(2024, 1, 15, 10, 30, 0)
+func dateFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	if len(args) >= 3 && args[0].Type() == object.NUMBER_OBJ {
+		parts := [6]int{0, 1, 1, 0, 0, 0}
+
+		if len(args) > 6 {
+			return newError(tok, "tarih(...) için yanlış sayıda argüman: bulunan=%d, en fazla=6", len(args))
+		}
+
+		for i, arg := range args {
+			n, ok := arg.(*object.Number)
+			if !ok {
+				return newError(tok, "tarih(...) argümanı %d sayı olmalı, bulunan %s", i, arg.Type())
+			}
+
+			parts[i] = n.Int()
+		}
+
+		return &object.Time{Token: tok, Value: time.Date(parts[0], time.Month(parts[1]), parts[2], parts[3], parts[4], parts[5], 0, time.Local)}
+	}
+
+	err, spec := validateVarArgs(tok, "tarih", args, [][][]string{
+		{{object.NUMBER_OBJ}},
+		{{object.STRING_OBJ}},
+		{{object.STRING_OBJ}, {object.STRING_OBJ}},
+		{{object.STRING_OBJ}, {object.STRING_OBJ}, {object.STRING_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if spec == 0 {
+		ms := args[0].(*object.Number).Value
+		return &object.Time{Token: tok, Value: time.Unix(0, int64(ms*float64(time.Millisecond)))}
+	}
+
+	input := translateTurkishNames(args[0].(*object.String).Value)
+	layouts := defaultLayouts
+	loc := time.Local
+
+	if spec >= 2 {
+		layouts = []string{toGoLayout(args[1].(*object.String).Value)}
+	}
+
+	if spec == 3 {
+		l, err := loadLocation(tok, args[2].(*object.String).Value)
+		if err != nil {
+			return err
+		}
+
+		loc = l
+	}
+
+	var parseErr error
+	for _, layout := range layouts {
+		t, e := time.ParseInLocation(layout, input, loc)
+		if e == nil {
+			return &object.Time{Token: tok, Value: t}
+		}
+
+		parseErr = e
+	}
+
+	if spec == 1 {
+		return newError(tok, "tarih ayrıştırılamadı %s: ISO 8601 ya da YYYY-AA-GG biçiminde olmalı", args[0].Inspect())
+	}
+
+	return newError(tok, "tarih ayrıştırılamadı %s: %s", args[0].Inspect(), parseErr.Error())
+}
+
+func formatTimeFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err, spec := validateVarArgs(tok, "biçimle", args, [][][]string{
+		{{object.TIME_OBJ}, {object.STRING_OBJ}, {object.STRING_OBJ}},
+		{{object.TIME_OBJ}, {object.STRING_OBJ}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	lang := "tr"
+	if spec == 0 {
+		lang = args[2].(*object.String).Value
+
+		if lang != "tr" && lang != "en" {
+			return newError(tok, "desteklenmeyen dil: %s (\"tr\" ya da \"en\" olmalı)", lang)
+		}
+	}
+
+	return &object.String{Token: tok, Value: formatTime(args[0].(*object.Time).Value, args[1].(*object.String).Value, lang)}
+}
+
+func inZoneFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "bölgede", args, 2, [][]string{{object.TIME_OBJ}, {object.STRING_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	loc, err := loadLocation(tok, args[1].(*object.String).Value)
+	if err != nil {
+		return err
+	}
+
+	return &object.Time{Token: tok, Value: args[0].(*object.Time).Value.In(loc)}
+}
+
+func addDurationFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "ekle", args, 2, [][]string{{object.TIME_OBJ}, {object.NUMBER_OBJ, object.STRING_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	d, err := durationArg(tok, args[1])
+	if err != nil {
+		return err
+	}
+
+	return &object.Time{Token: tok, Value: args[0].(*object.Time).Value.Add(d)}
+}
+
+func addDateFn(name string, years, months, days int) object.BuiltinFunction {
+	return func(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+		err := validateArgs(tok, name, args, 2, [][]string{{object.TIME_OBJ}, {object.NUMBER_OBJ}})
+		if err != nil {
+			return err
+		}
+
+		n := args[1].(*object.Number).Int()
+		return &object.Time{Token: tok, Value: args[0].(*object.Time).Value.AddDate(years*n, months*n, days*n)}
+	}
+}
+
+func durationFn(tok token.Token, env *object.Environment, args ...object.Object) object.Object {
+	err := validateArgs(tok, "süre", args, 1, [][]string{{object.STRING_OBJ}})
+	if err != nil {
+		return err
+	}
+
+	d, err := durationArg(tok, args[0])
+	if err != nil {
+		return err
+	}
+
+	return &object.Number{Token: tok, Value: float64(d) / float64(time.Millisecond)}
+}
+
+func timeProperty(tok token.Token, t time.Time, name string) (object.Object, bool) {
+	var value int64
+
+	switch name {
+	case "yıl":
+		value = int64(t.Year())
+	case "ay":
+		value = int64(t.Month())
+	case "gün":
+		value = int64(t.Day())
+	case "saat":
+		value = int64(t.Hour())
+	case "dakika":
+		value = int64(t.Minute())
+	case "saniye":
+		value = int64(t.Second())
+	case "milisaniye":
+		value = int64(t.Nanosecond()) / int64(time.Millisecond)
+	case "hafta_günü":
+		// ISO 8601: pazartesi 1, pazar 7
+		value = int64(t.Weekday()+6)%7 + 1
+	case "yılın_günü":
+		value = int64(t.YearDay())
+	case "unix_ms":
+		value = t.UnixNano() / int64(time.Millisecond)
+	case "bölge":
+		return &object.String{Token: tok, Value: t.Location().String()}, true
+	default:
+		return nil, false
+	}
+
+	return object.NewInteger(tok, value), true
+}
+
+func evalTimeInfixExpression(tok token.Token, operator string, left, right object.Object) object.Object {
+	l := left.(*object.Time).Value
+
+	if r, ok := right.(*object.Number); ok {
+		d := time.Duration(r.Value * float64(time.Millisecond))
+
+		switch operator {
+		case "+":
+			return &object.Time{Token: tok, Value: l.Add(d)}
+		case "-":
+			return &object.Time{Token: tok, Value: l.Add(-d)}
+		}
+
+		return newError(tok, "Bilinmeyen operatör: %s %s %s", left.Type(), operator, right.Type())
+	}
+
+	r := right.(*object.Time).Value
+
+	switch operator {
+	case "-":
+		return &object.Number{Token: tok, Value: float64(l.Sub(r)) / float64(time.Millisecond)}
+	case "==":
+		return nativeBoolToBooleanObject(l.Equal(r))
+	case "!=":
+		return nativeBoolToBooleanObject(!l.Equal(r))
+	case "<":
+		return nativeBoolToBooleanObject(l.Before(r))
+	case "<=":
+		return nativeBoolToBooleanObject(!l.After(r))
+	case ">":
+		return nativeBoolToBooleanObject(l.After(r))
+	case ">=":
+		return nativeBoolToBooleanObject(!l.Before(r))
+	}
+
+	return newError(tok, "Bilinmeyen operatör: %s %s %s", left.Type(), operator, right.Type())
+}
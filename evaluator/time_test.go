@@ -0,0 +1,57 @@
+package evaluator
+
+import "testing"
+
+func TestTime(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{`tarih("2024-01-15", "%Y-%m-%d", "UTC")`, "2024-01-15T00:00:00Z"},
+		{`tarih("2024-01-15").yıl`, "2024"},
+		{`tarih("2024-01-15 10:30").saat`, "10"},
+		{`biçimle(tarih("15 Ocak 2024", "%d %B %Y"), "%A %d %b")`, "Pazartesi 15 Oca"},
+		{`biçimle(tarih(2024, 1, 15, 10, 30), "%A", "en")`, "Monday"},
+		{`biçimle(tarih(2024, 2, 29), "%F %T")`, "2024-02-29 00:00:00"},
+		{`biçimle(tarih("2024-01-15 09:05", "%Y-%m-%d %H:%M", "UTC"), "iso")`, "2024-01-15T09:05:00Z"},
+		{`tarih(2024, 1, 15).hafta_günü`, "1"},
+		{`tarih(2024, 3, 1).yılın_günü`, "61"},
+		{`tarih(0).bölgede("UTC").yıl`, "1970"},
+		{`biçimle(tarih("2024-01-15T10:00:00Z").bölgede("Europe/Istanbul"), "%H:%M")`, "13:00"},
+		{`tarih("2024-01-15T10:00:00Z").bölgede("Europe/Istanbul").bölge`, "Europe/Istanbul"},
+		{`biçimle(tarih(2024, 1, 15).gün_ekle(-20), "%F")`, "2023-12-26"},
+		{`biçimle(tarih(2024, 1, 31).ay_ekle(1), "%F")`, "2024-03-02"},
+		{`biçimle(tarih(2024, 2, 29).yıl_ekle(1), "%F")`, "2025-03-01"},
+		{`biçimle(tarih(2024, 1, 15).ekle("1h30m"), "%T")`, "01:30:00"},
+		{`süre("1h30m")`, "5400000"},
+		{`tarih(2024, 1, 2) - tarih(2024, 1, 1)`, "86400000"},
+		{`biçimle(tarih(2024, 1, 1) + 1000, "%T")`, "00:00:01"},
+		{`tarih(2024, 1, 2) > tarih(2024, 1, 1)`, "true"},
+		{`tarih("2024-01-15T10:00:00Z") == tarih("2024-01-15T13:00:00+03:00")`, "true"},
+	}
+
+	for _, tt := range tests {
+		expect(t, tt.code, tt.want)
+	}
+
+	errors := []struct {
+		code    string
+		message string
+	}{
+		{`tarih("15/01/2024")`, "tarih ayrıştırılamadı 15/01/2024: ISO 8601 ya da YYYY-AA-GG biçiminde olmalı"},
+		{`tarih("2024-13-01", "%Y-%m-%d")`, "tarih ayrıştırılamadı 2024-13-01: parsing time"},
+		{`tarih("15 Ocak", "%d.%m.%Y")`, "tarih ayrıştırılamadı 15 Ocak"},
+		{`tarih("2024-01-15", "%Y-%m-%d", "Mars/Olympus")`, "bilinmeyen saat dilimi: Mars/Olympus"},
+		{`şimdi().bölgede("Yok/Yer")`, "bilinmeyen saat dilimi: Yok/Yer"},
+		{`biçimle(şimdi(), "%Y", "de")`, "desteklenmeyen dil: de"},
+		{`süre("bir saat")`, "geçersiz süre: bir saat"},
+		{`şimdi().ekle([1])`, "1 argümanı ekle(...) için destekli değildir"},
+		{`tarih(2024, "1", 1)`, "tarih(...) argümanı 1 sayı olmalı, bulunan STRING"},
+		{`tarih(1, 2, 3, 4, 5, 6, 7)`, "tarih(...) için yanlış sayıda argüman: bulunan=7, en fazla=6"},
+		{`tarih(2024, 1, 1) * 2`, "Bilinmeyen operatör: TIME * NUMBER"},
+	}
+
+	for _, tt := range errors {
+		expectError(t, tt.code, tt.message)
+	}
+}
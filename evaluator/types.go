@@ -0,0 +1,116 @@
+package evaluator
+
+import (
+	"github.com/ankalang/anka/ast"
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+// yapıcı metodun adı: tip Kişi { f kur(ad) { bu.ad = ad } }
+const constructorName = "kur"
+
+// metotlarda örneğin kendisine bu adla erişilir
+const receiverName = "bu"
+
+func evalTypeStatement(node *ast.TypeStatement, env *object.Environment) object.Object {
+	td := &object.TypeDef{Token: node.Token, Name: node.Name, Fields: node.Fields, Methods: map[string]*object.Function{}, Env: env}
+	env.Capture()
+	seen := map[string]bool{}
+
+	for _, f := range node.Fields {
+		if seen[f.Name] {
+			return newError(node.Token, "%s tipinde '%s' iki kez tanımlanmış", node.Name, f.Name)
+		}
+
+		seen[f.Name] = true
+	}
+
+	for _, m := range node.Methods {
+		if seen[m.Name] {
+			return newError(m.Token, "%s tipinde '%s' iki kez tanımlanmış", node.Name, m.Name)
+		}
+
+		seen[m.Name] = true
+		td.Methods[m.Name] = &object.Function{Token: m.Token, Parameters: m.Parameters, Env: env, Body: m.Body, Name: m.Name, Node: m}
+	}
+
+	if err := env.Scope().Declare(node.Name, td, false); err != nil {
+		return newError(node.Token, "%s", err.Error())
+	}
+
+	return NULL
+}
+
+// bindMethod metodu, bu değişkeni örneği gösterecek şekilde bağlar
+func bindMethod(fn *object.Function, instance *object.Instance) *object.Function {
+	env := object.NewEnclosedEnvironment(fn.Env, nil)
+	env.Set(receiverName, instance)
+
+	return &object.Function{Token: fn.Token, Parameters: fn.Parameters, Env: env, Body: fn.Body, Name: fn.Name, Node: fn.Node}
+}
+
+// Kişi("Ali", 30) önce varsayılan değerleri hesaplar, sonra varsa kur metodunu çağırır,
+// yoksa argümanları alanlara tanımlandıkları sırayla atar
+func instantiate(tok token.Token, td *object.TypeDef, env *object.Environment, args []object.Object, keywords []object.KeywordArgument) object.Object {
+	instance := &object.Instance{Token: tok, Of: td, Fields: &object.Hash{Token: tok}}
+
+	for _, f := range td.Fields {
+		var value object.Object = NULL
+
+		if f.Default != nil {
+			value = Eval(f.Default, td.Env)
+			if isError(value) {
+				return value
+			}
+		}
+
+		instance.Fields.Set(&object.String{Token: tok, Value: f.Name}, value)
+	}
+
+	if constructor, ok := td.Methods[constructorName]; ok {
+		result := callFunction(tok, bindMethod(constructor, instance), env, args, keywords)
+		if isError(result) {
+			return result
+		}
+
+		return instance
+	}
+
+	if len(args) > len(td.Fields) {
+		return newError(tok, "%s en fazla %d argüman alır, %d verildi", td.Name, len(td.Fields), len(args))
+	}
+
+	for i, arg := range args {
+		instance.Fields.Set(&object.String{Token: tok, Value: td.Fields[i].Name}, arg)
+	}
+
+	// Kişi(ad: "Ali") alanları adlarıyla atar
+	for _, k := range keywords {
+		if !td.HasField(k.Name) {
+			return newError(k.Token, "%s tipinin '%s' adında bir alanı yok", td.Name, k.Name)
+		}
+
+		for i := range args {
+			if td.Fields[i].Name == k.Name {
+				return newError(k.Token, "%s tipinin '%s' alanına hem sıralı hem isimli argüman verildi", td.Name, k.Name)
+			}
+		}
+
+		instance.Fields.Set(&object.String{Token: tok, Value: k.Name}, k.Value)
+	}
+
+	return instance
+}
+
+// instanceMember önce alanlara, sonra metotlara bakar
+func instanceMember(instance *object.Instance, name string) (object.Object, bool) {
+	if pair, ok := instance.Fields.GetPair(name); ok {
+		return pair.Value, true
+	}
+
+	if method, ok := instance.Of.Methods[name]; ok {
+		return bindMethod(method, instance), true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,76 @@
+package evaluator
+
+import "testing"
+
+func TestTypes(t *testing.T) {
+	types := `
+tip Kişi {
+    ad
+    yaş = 0
+    f selam() { dön "merhaba " + bu.ad }
+    f büyü() {
+        bu.yaş += 1
+        dön bu
+    }
+}
+
+tip Nokta {
+    x = 0
+    y = 0
+    f kur(x, y = x) {
+        bu.x = x * 10
+        bu.y = y
+    }
+    f topla() { dön bu.x + bu.y }
+}
+
+tip Boş {}
+`
+
+	tests := []struct {
+		code string
+		want string
+	}{
+		// kur yoksa argümanlar alanlara sırayla atanır
+		{`Kişi("Ali", 30)`, `Kişi{"ad": "Ali", "yaş": 30}`},
+		{`Kişi("Ali")`, `Kişi{"ad": "Ali", "yaş": 0}`},
+		{`Kişi()`, `Kişi{"ad": null, "yaş": 0}`},
+		{`Kişi(yaş: 3, ad: "Can")`, `Kişi{"ad": "Can", "yaş": 3}`},
+		{`Boş()`, "Boş{}"},
+		{`Kişi("Ali").selam()`, "merhaba Ali"},
+		{`Kişi("Ali").büyü().büyü().yaş`, "2"},
+		{"k = Kişi(\"Ali\")\nk.ad = \"Veli\"\nk.selam()", "merhaba Veli"},
+		{"k = Kişi(\"Ali\")\nselam = k.selam\nk.ad = \"Veli\"\nselam()", "merhaba Veli"},
+		{`tip(Kişi("Ali"))`, "Kişi"},
+		{`Kişi("Ali") == Kişi("Ali")`, "false"},
+		// kur varsa argümanları o karşılar
+		{`Nokta(1)`, `Nokta{"x": 10, "y": 1}`},
+		{`Nokta(1, 2).topla()`, "12"},
+		{`Nokta(y: 5, x: 1)`, `Nokta{"x": 10, "y": 5}`},
+	}
+
+	for _, tt := range tests {
+		expect(t, types+tt.code, tt.want)
+	}
+
+	errors := []struct {
+		code    string
+		message string
+	}{
+		{`Kişi("Ali", 30, 1)`, "Kişi en fazla 2 argüman alır, 3 verildi"},
+		{`Boş(1)`, "Boş en fazla 0 argüman alır, 1 verildi"},
+		{`Kişi(boy: 1)`, "Kişi tipinin 'boy' adında bir alanı yok"},
+		{`Kişi("Ali", ad: "Can")`, "Kişi tipinin 'ad' alanına hem sıralı hem isimli argüman verildi"},
+		{"k = Kişi(\"Ali\")\nk.boy = 1", "'boy' özelliği, Kişi tipinde geçersizdir"},
+		{`Kişi("Ali").boy`, "'boy' özelliği, Kişi tipinde geçersizdir"},
+		{`Kişi("Ali").uç()`, "uç() metodu mevcut değil"},
+		{`Nokta()`, "kur fonksiyonu için x argümanı bulunamadı"},
+	}
+
+	for _, tt := range errors {
+		expectError(t, types+tt.code, tt.message)
+	}
+
+	expectError(t, "tip A {\n    x\n    x\n}", "A tipinde 'x' iki kez tanımlanmış")
+	expectError(t, "tip A {\n    x\n    f x() {}\n}", "A tipinde 'x' iki kez tanımlanmış")
+}
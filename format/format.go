@@ -0,0 +1,306 @@
+package format
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/ankalang/anka/ast"
+	"github.com/ankalang/anka/lexer"
+	"github.com/ankalang/anka/parser"
+	"github.com/ankalang/anka/token"
+)
+
+const indent = "    "
+
+// ParseError biçimlenmek istenen kodun ayrıştırıcı hatalarıdır
+type ParseError struct {
+	Errors []string
+}
+
+func (e *ParseError) Error() string {
+	msg := " Ayrıştırıcı hatası:\n"
+	for _, err := range e.Errors {
+		msg += "\t" + err + "\n"
+	}
+
+	return msg
+}
+
+// Source kodu biçimler. Biçimleyici yalnızca boşlukları değiştirir: girinti
+// her açık parantez düzeyi için dört boşluktur, işleçlerin çevresine tek
+// boşluk konur, yorumlar ve satır sonları korunur, art arda boş satırlar teke
+// iner. Ayrıştırılamayan kod biçimlenmez.
+func Source(code string) (string, error) {
+	p := parser.New(lexer.New(code))
+	program := p.ParseProgram()
+
+	if len(p.Errors()) != 0 {
+		return "", &ParseError{Errors: p.Errors()}
+	}
+
+	items := scan(code)
+	pr := &printer{hashes: hashBraces(program)}
+	out := pr.print(items)
+
+	// biçimlenmiş kod aynı tokenlerden oluşmalı; oluşmuyorsa bir boşluk iki
+	// tokeni birleştirmiş ya da ayırmıştır
+	if !sameTokens(items, scan(out)) {
+		return "", errors.New("biçimlenmiş kod özgün kodla aynı tokenlerden oluşmuyor")
+	}
+
+	return out, nil
+}
+
+// item kaynaktaki bir tokendir
+type item struct {
+	tok  token.Token
+	text string
+	// tokenden önceki satır sonu sayısı
+	newlines int
+}
+
+func scan(code string) []item {
+	input := []rune(code)
+	l := lexer.New(code)
+	l.KeepComments()
+
+	items := []item{}
+	end := 0
+
+	for {
+		tok := l.NextToken()
+		if tok.Type == token.EOF {
+			break
+		}
+
+		// bazı işleçlerin konumu son karakterlerini gösterir, satır sonları
+		// yine de işleçten önce kalır
+		raw := strings.TrimRight(string(input[tok.Position:l.CurrentPosition()]), " \t\r\n")
+		it := item{tok: tok, text: tokenText(tok, raw)}
+
+		for _, ch := range input[end:tok.Position] {
+			if ch == '\n' {
+				it.newlines++
+			}
+		}
+
+		end = tok.Position + len([]rune(raw))
+		items = append(items, it)
+	}
+
+	return items
+}
+
+// tokenText sabitlerin kaynaktaki yazılışını, diğer tokenlerin kendisini döner
+func tokenText(tok token.Token, raw string) string {
+	switch tok.Type {
+	case token.STRING, token.COMMAND, token.NUMBER, token.INT, token.DECIMAL, token.COMMENT:
+		return raw
+	}
+
+	return tok.Literal
+}
+
+// hashBraces harita sabitlerinin ve kalıplarının süslü parantezlerini bulur;
+// diğer süslü parantezler bloktur
+func hashBraces(program *ast.Program) map[int]bool {
+	hashes := map[int]bool{}
+
+	ast.Inspect(program, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.HashLiteral:
+			hashes[n.Token.Position] = true
+		case *ast.HashPattern:
+			hashes[n.Token.Position] = true
+		}
+
+		return true
+	})
+
+	return hashes
+}
+
+func sameTokens(a, b []item) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].tok.Type != b[i].tok.Type || a[i].text != b[i].text {
+			return false
+		}
+	}
+
+	return true
+}
+
+type bracket struct {
+	tok  token.TokenType
+	hash bool
+	line int
+	// sonraki satırlar bu parantez için girintilenir
+	indents bool
+}
+
+type printer struct {
+	out    bytes.Buffer
+	hashes map[int]bool
+	stack  []bracket
+	line   int
+	// son token tekli bir işleçti
+	unary bool
+	// son kapanan parantez bir harita sabitinindi
+	closedHash bool
+}
+
+func (p *printer) print(items []item) string {
+	for i, it := range items {
+		if i == 0 || it.newlines > 0 {
+			if i > 0 {
+				p.endLine()
+				p.out.WriteString("\n")
+
+				if it.newlines > 1 {
+					p.out.WriteString("\n")
+				}
+			}
+
+			p.line++
+			p.out.WriteString(strings.Repeat(indent, p.indentation(items[i:])))
+		} else if p.space(items[i-1], it) {
+			p.out.WriteString(" ")
+		}
+
+		p.out.WriteString(it.text)
+		p.unary = isUnary(it.tok.Type) && (i == 0 || it.newlines > 0 || !isOperand(items[i-1].tok.Type))
+		p.track(it)
+	}
+
+	if len(items) > 0 {
+		p.out.WriteString("\n")
+	}
+
+	return p.out.String()
+}
+
+// indentation satırın girintisidir; satırın başındaki kapanan parantezler
+// girintiden düşülür
+func (p *printer) indentation(line []item) int {
+	open := len(p.stack)
+	for i, it := range line {
+		if (i > 0 && it.newlines > 0) || !isCloser(it.tok.Type) || open == 0 {
+			break
+		}
+
+		open--
+	}
+
+	n := 0
+	for _, b := range p.stack[:open] {
+		if b.indents {
+			n++
+		}
+	}
+
+	return n
+}
+
+// endLine satırda açılıp kapanmayan parantezlerden en içtekine sonraki
+// satırları girintiletir; bir satırda birden fazla parantez açılsa da girinti
+// bir düzey artar
+func (p *printer) endLine() {
+	if n := len(p.stack); n > 0 && p.stack[n-1].line == p.line {
+		p.stack[n-1].indents = true
+	}
+}
+
+func (p *printer) track(it item) {
+	switch it.tok.Type {
+	case token.LPAREN, token.LBRACKET, token.LBRACE:
+		p.stack = append(p.stack, bracket{
+			tok:  it.tok.Type,
+			hash: it.tok.Type == token.LBRACE && p.hashes[it.tok.Position],
+			line: p.line,
+		})
+	case token.RPAREN, token.RBRACKET, token.RBRACE:
+		if len(p.stack) > 0 {
+			p.closedHash = p.top().hash
+			p.stack = p.stack[:len(p.stack)-1]
+		}
+	}
+}
+
+func (p *printer) top() bracket {
+	if len(p.stack) == 0 {
+		return bracket{}
+	}
+
+	return p.stack[len(p.stack)-1]
+}
+
+// space aynı satırdaki iki token arasına boşluk konup konmayacağını söyler
+func (p *printer) space(prev, cur item) bool {
+	prevType, curType := prev.tok.Type, cur.tok.Type
+
+	switch {
+	case curType == token.COMMENT:
+		return true
+	case prevType == token.LBRACE:
+		// blok içi boşluklu, harita sabiti boşluksuz yazılır: { dön x }, {"a": 1}
+		return curType != token.RBRACE && !p.top().hash
+	case curType == token.RBRACE:
+		return prevType != token.LBRACE && !p.top().hash
+	case p.unary:
+		return false
+	}
+
+	switch prevType {
+	case token.LPAREN, token.LBRACKET, token.DOT, token.AT, token.CURRENT_ARGS, token.RANGE, token.QUESTION:
+		return false
+	case token.COLON:
+		// dilimlerde iki nokta boşluksuzdur: d[1:3]
+		return p.top().tok != token.LBRACKET
+	}
+
+	switch curType {
+	case token.RPAREN, token.RBRACKET, token.COMMA, token.SEMICOLON, token.DOT, token.COLON, token.RANGE, token.QUESTION:
+		return false
+	case token.LPAREN:
+		// çağrılar ve fonksiyon tanımları: topla(1), f(x)
+		switch prevType {
+		case token.IDENT, token.RPAREN, token.RBRACKET, token.FUNCTION:
+			return false
+		}
+	case token.LBRACKET:
+		// indeksler: d[0], "abc"[1], f()[0], {"a": 1}["a"]
+		switch prevType {
+		case token.IDENT, token.RPAREN, token.RBRACKET, token.STRING:
+			return false
+		case token.RBRACE:
+			return !p.closedHash
+		}
+	}
+
+	return true
+}
+
+func isUnary(t token.TokenType) bool {
+	return t == token.MINUS || t == token.BANG || t == token.TILDE
+}
+
+// isOperand bir ifadenin sonu olabilecek tokenleri söyler; bunlardan sonra
+// gelen eksi işareti çıkarmadır
+func isOperand(t token.TokenType) bool {
+	switch t {
+	case token.IDENT, token.INT, token.NUMBER, token.DECIMAL, token.STRING, token.COMMAND,
+		token.TRUE, token.FALSE, token.NULL, token.RPAREN, token.RBRACKET, token.RBRACE, token.CURRENT_ARGS:
+		return true
+	}
+
+	return false
+}
+
+func isCloser(t token.TokenType) bool {
+	return t == token.RPAREN || t == token.RBRACKET || t == token.RBRACE
+}
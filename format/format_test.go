@@ -0,0 +1,86 @@
+package format
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"x=1+2", "x = 1 + 2\n"},
+		{"d = [1,2 ,3]", "d = [1, 2, 3]\n"},
+		{`h={"a":1,"b":[1,2]}`, "h = {\"a\": 1, \"b\": [1, 2]}\n"},
+		{`eko({"a": 1} ["a"])`, "eko({\"a\": 1}[\"a\"])\n"},
+		{`x = {"a": [1]}["a"] [0]`, "x = {\"a\": [1]}[\"a\"][0]\n"},
+		{"x = d [1:3]", "x = d[1:3]\n"},
+		{"x = g( 1 )[0]", "x = g(1)[0]\n"},
+		{"x = - y + ! z", "x = -y + !z\n"},
+		{"x = a - -b", "x = a - -b\n"},
+		{"eğer x {dön 1}", "eğer x { dön 1 }\n"},
+		{"f topla(a,b){\ndön a+b\n}", "f topla(a, b) {\n    dön a + b\n}\n"},
+		{"d = [\n1,\n2\n]", "d = [\n    1,\n    2\n]\n"},
+		{"a = 1\n\n\n\nb = 2", "a = 1\n\nb = 2\n"},
+		{"x = 1   # yorum", "x = 1 # yorum\n"},
+		{"döngü i in 1..3 {eko(i)}", "döngü i in 1..3 { eko(i) }\n"},
+		{"x = y?.z", "x = y?.z\n"},
+	}
+
+	for _, tt := range tests {
+		got, err := Source(tt.in)
+		if err != nil {
+			t.Errorf("%q: %s", tt.in, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("%q\nbulunan: %q\nistenen: %q", tt.in, got, tt.want)
+		}
+
+		// biçimlenmiş kod yeniden biçimlenince değişmemeli
+		again, err := Source(got)
+		if err != nil || again != got {
+			t.Errorf("%q yeniden biçimlenince değişti: %q", got, again)
+		}
+	}
+}
+
+// örnek programlar zaten biçimlidir
+func TestSourceKeepsFormattedPrograms(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "repl", "testdata", "*.ank"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) == 0 {
+		t.Fatal("örnek program yok")
+	}
+
+	for _, file := range files {
+		code, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := Source(string(code))
+		if err != nil {
+			t.Errorf("%s: %s", file, err)
+			continue
+		}
+
+		if got != string(code) {
+			t.Errorf("%s biçimlenince değişti:\n%s", file, got)
+		}
+	}
+}
+
+func TestSourceParseError(t *testing.T) {
+	_, err := Source("x = (1 +")
+
+	if _, ok := err.(*ParseError); !ok {
+		t.Fatalf("ayrıştırıcı hatası bekleniyordu, bulunan %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package format
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+const usage = "kullanım: anka biçimle [--kontrol] dosya.ank ..."
+
+// Run `anka biçimle` komutunu çalıştırır ve çıkış kodunu döner. Biçimlenmiş
+// kod ekrana yazılır; --kontrol ile yalnızca biçimli olmayan dosyalar
+// listelenir ve böyle bir dosya varsa 1 döner.
+func Run(args []string) int {
+	check := false
+	files := []string{}
+
+	for _, arg := range args {
+		if arg == "--kontrol" {
+			check = true
+			continue
+		}
+
+		files = append(files, arg)
+	}
+
+	if len(files) == 0 {
+		fmt.Println(usage)
+		return 99
+	}
+
+	status := 0
+	for _, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s dosyası okunamadı: %s\n", file, err.Error())
+			return 99
+		}
+
+		code := string(b)
+		out, err := Source(code)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s:%s\n", file, err.Error())
+			return 99
+		}
+
+		if !check {
+			fmt.Print(out)
+			continue
+		}
+
+		if out != code {
+			fmt.Println(file)
+			status = 1
+		}
+	}
+
+	return status
+}
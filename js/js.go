@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"syscall/js"
 
 	"github.com/ankalang/anka/evaluator"
@@ -32,7 +33,7 @@ func runCode(this js.Value, i []js.Value) interface{} {
 		return js.ValueOf(m)
 	}
 
-	result := evaluator.BeginEval(program, env, lex)
+	result := evaluator.NewInterpreter(strings.NewReader("")).Run(program, env)
 	m["out"] = buf.String()
 	m["result"] = result.Inspect()
 
@@ -14,6 +14,9 @@ type Lexer struct {
 	input        []rune
 	
 	lineMap [][2]int 
+	file    string
+	// yorumlar atlanmak yerine token olarak döner
+	comments bool
 }
 
 func New(in string) *Lexer {
@@ -25,6 +28,23 @@ func New(in string) *Lexer {
 	return l
 }
 
+// NewFile dosyadan okunan kodu tarar; hatalar dosyanın adıyla gösterilir
+func NewFile(in string, file string) *Lexer {
+	l := New(in)
+	l.file = file
+	return l
+}
+
+func (l *Lexer) File() string {
+	return l.file
+}
+
+// KeepComments yorumları COMMENT tokenleri olarak döndürür; biçimleyici gibi
+// kaynağı olduğu gibi yeniden yazan araçlar içindir, ayrıştırıcı yorum beklemez
+func (l *Lexer) KeepComments() {
+	l.comments = true
+}
+
 
 func (l *Lexer) buildLineMap() {
 	begin := 0
@@ -77,6 +97,12 @@ func (l *Lexer) newToken(tokenType token.TokenType) token.Token {
 }
 
 func (l *Lexer) NextToken() token.Token {
+	tok := l.nextToken()
+	tok.Source = l
+	return tok
+}
+
+func (l *Lexer) nextToken() token.Token {
 	var tok token.Token
 
 	l.skipWhitespace()
@@ -89,6 +115,11 @@ func (l *Lexer) NextToken() token.Token {
 			l.readChar()
 			literal := string(ch) + string(l.ch)
 			tok.Literal = literal
+		} else if l.peekChar() == '>' {
+			tok.Type = token.ARROW
+			tok.Position = l.position
+			tok.Literal = "=>"
+			l.readChar()
 		} else {
 			tok = l.newToken(token.ASSIGN)
 		}
@@ -135,7 +166,9 @@ func (l *Lexer) NextToken() token.Token {
 			tok = l.newToken(token.BANG)
 		}
 	case '/':
-		if l.peekChar() == '/' {
+		if l.peekChar() == '/' && l.comments {
+			return l.comment()
+		} else if l.peekChar() == '/' {
 			
 			_ = l.readLine()
 			l.readChar()
@@ -149,7 +182,10 @@ func (l *Lexer) NextToken() token.Token {
 			tok = l.newToken(token.SLASH)
 		}
 	case '#':
-		
+		if l.comments {
+			return l.comment()
+		}
+
 		_ = l.readLine()
 		l.readChar()
 		return l.NextToken()
@@ -306,6 +342,15 @@ func (l *Lexer) NextToken() token.Token {
 		} else if isDigit(l.ch) {
 			tok.Position = l.position
 			literal, kind := l.readNumber()
+			if kind == token.NUMBER && !strings.ContainsAny(literal, ".e") {
+				kind = token.INT
+			}
+
+			// 12.30d ondalık sayı sabitidir
+			if kind != token.ILLEGAL && l.ch == 'd' && !isLetter(l.peekChar()) && !isDigit(l.peekChar()) {
+				l.readChar()
+				kind = token.DECIMAL
+			}
 			tok.Type = kind
 			tok.Literal = literal
 			return tok
@@ -318,6 +363,23 @@ func (l *Lexer) NextToken() token.Token {
 	return tok
 }
 
+// StartsLine verilen konumdaki tokenden önce satırda yalnızca boşluk olup
+// olmadığını söyler
+func (l *Lexer) StartsLine(pos int) bool {
+	for i := pos - 1; i >= 0; i-- {
+		switch l.input[i] {
+		case ' ', '\t', '\r':
+			continue
+		case '\n':
+			return true
+		}
+
+		return false
+	}
+
+	return true
+}
+
 func (l *Lexer) skipWhitespace() {
 	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
 		l.readChar()
@@ -521,6 +583,13 @@ func (l *Lexer) readString(quote byte) string {
 
 
 
+// comment satırın sonuna kadar olan yorumu okur; satır sonu okunmaz
+func (l *Lexer) comment() token.Token {
+	tok := token.Token{Type: token.COMMENT, Position: l.position}
+	tok.Literal = l.readLine()
+	return tok
+}
+
 func (l *Lexer) readLine() string {
 	position := l.position
 	for {
@@ -0,0 +1,712 @@
+package lint
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ankalang/anka/ast"
+	"github.com/ankalang/anka/evaluator"
+	"github.com/ankalang/anka/lexer"
+	"github.com/ankalang/anka/parser"
+	"github.com/ankalang/anka/token"
+	"github.com/ankalang/anka/util"
+)
+
+// Severity raporun önemidir; hatalar program çalıştırıldığında da hata
+// verecek kodlardır
+type Severity int
+
+const (
+	Warning Severity = iota
+	Error
+)
+
+func (s Severity) String() string {
+	if s == Error {
+		return "hata"
+	}
+
+	return "uyarı"
+}
+
+// Diagnostic denetleyicinin koddaki bir konum için raporudur
+type Diagnostic struct {
+	Severity Severity
+	Line     int
+	Column   int
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s", d.Line, d.Column, d.Severity, d.Message)
+}
+
+// yorumlayıcının her programda tanımladığı adlar
+var predeclared = []string{"ANK_VERSION", "ANK_INTERACTIVE"}
+
+// Check programı çalıştırmadan denetler: tanımsız adlar, yerleşik
+// fonksiyonlara yanlış sayıda ya da isimli argüman verilmesi ve döngü dışında
+// dur ya da devam hata; fonksiyonlarda kullanılmayan değişkenler ve dön, dur
+// ya da devam'dan sonra gelen kod uyarıdır. file programın dosyasıdır, kaynak
+// ile yüklenen dosyalar buna göre bulunur.
+func Check(program *ast.Program, file string) []Diagnostic {
+	c := &checker{dir: filepath.Dir(file), scope: newScope(nil), sourced: map[string]bool{}}
+
+	for _, name := range predeclared {
+		c.scope.declare(name, token.Token{}, false)
+	}
+
+	c.collect(program, false)
+	c.walk(program)
+
+	sort.SliceStable(c.diagnostics, func(i, j int) bool {
+		a, b := c.diagnostics[i], c.diagnostics[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+
+		return a.Column < b.Column
+	})
+
+	return c.diagnostics
+}
+
+type checker struct {
+	dir   string
+	scope *scope
+	// içinde bulunulan fonksiyondaki iç içe döngü sayısı
+	loops int
+	// kaynak ile yüklenmiş dosyalar
+	sourced     map[string]bool
+	diagnostics []Diagnostic
+}
+
+func (c *checker) report(severity Severity, tok token.Token, format string, a ...interface{}) {
+	d := Diagnostic{Severity: severity, Message: fmt.Sprintf(format, a...)}
+	if tok.Source != nil {
+		d.Line, d.Column, _ = tok.Source.ErrorLine(tok.Position)
+	}
+
+	c.diagnostics = append(c.diagnostics, d)
+}
+
+// collect fonksiyonun ya da dosyanın adlarını, kullanımlar denetlenmeden
+// önce toplar; böylece fonksiyonlar dosyada kendilerinden sonra tanımlanan
+// adları kullanabilir. Bloklardaki bildirimler blok denetlenirken toplanır,
+// iç fonksiyonların gövdeleri kendi kapsamlarında toplanır.
+func (c *checker) collect(node ast.Node, function bool) {
+	declarations(node, func(name string, tok token.Token) {
+		c.scope.declare(name, tok, function)
+	})
+
+	c.hoist(node, function, nil)
+}
+
+// hoist fonksiyon kapsamına yazan adları bloklar dahil toplar: fonksiyon ve
+// tip tanımları ile düz atamalar. shadowed içinde bulunulan bloklarda
+// tanımlanmış adlardır; bunlara yapılan düz atamalar bloktaki değişkeni
+// değiştirir.
+func (c *checker) hoist(node ast.Node, function bool, shadowed map[string]bool) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FunctionLiteral:
+			c.scope.declare(n.Name, n.Token, false)
+			return false
+		case *ast.TypeStatement:
+			c.scope.declare(n.Name, n.Token, false)
+			return false
+		case ast.Pattern:
+			// kalıpların adları atamalarda ve bloklarda toplanır
+			return false
+		case *ast.AssignStatement:
+			if n.Declaration == nil {
+				assigned(n, func(name string, tok token.Token) {
+					if !shadowed[name] {
+						c.assign(name, tok, function)
+					}
+				})
+			}
+		case *ast.BlockStatement:
+			if n != node {
+				c.hoistBlock(n, function, shadowed)
+				return false
+			}
+		case *ast.ForExpression:
+			// başlangıç ve adım atamaları döngü değişkenine yazar
+			c.hoistBlock(n.Block, function, with(shadowed, n.Identifier))
+			return false
+		case *ast.ForInExpression:
+			c.hoist(n.Iterable, function, shadowed)
+			c.hoistBlock(n.Block, function, with(shadowed, n.Key, n.Value))
+
+			// yoksa bloğu döngünün bulunduğu kapsamda çalışır
+			if n.Alternative != nil {
+				c.hoist(n.Alternative, function, shadowed)
+			}
+
+			return false
+		case *ast.TryExpression:
+			c.hoistBlock(n.Block, function, shadowed)
+			c.hoistBlock(n.Catch, function, with(shadowed, n.Identifier))
+			c.hoistBlock(n.Finally, function, shadowed)
+			return false
+		case *ast.MatchExpression:
+			c.hoist(n.Subject, function, shadowed)
+
+			for _, arm := range n.Arms {
+				names := []string{}
+				patternNames(arm.Pattern, func(name string, tok token.Token) {
+					names = append(names, name)
+				})
+
+				c.hoistBlock(arm.Body, function, with(shadowed, names...))
+			}
+
+			return false
+		case *ast.CallExpression:
+			if ident, ok := n.Function.(*ast.Identifier); ok {
+				switch ident.Value {
+				case "eval":
+					c.scope.dynamic = true
+				case "kaynak":
+					c.source(n)
+				}
+			}
+		}
+
+		return true
+	})
+}
+
+func (c *checker) hoistBlock(block *ast.BlockStatement, function bool, shadowed map[string]bool) {
+	if block == nil {
+		return
+	}
+
+	names := []string{}
+	declarations(block, func(name string, tok token.Token) {
+		names = append(names, name)
+	})
+
+	c.hoist(block, function, with(shadowed, names...))
+}
+
+// assign düz atamanın tanımladığı adı kapsama ekler. Ad dışarıda tanımlıysa
+// atama dıştaki değişkeni değiştirir, yeni bir ad tanımlamaz.
+func (c *checker) assign(name string, tok token.Token, function bool) {
+	if c.scope.outer != nil && c.scope.outer.lookup(name) != nil {
+		return
+	}
+
+	c.scope.declare(name, tok, function)
+}
+
+// declarations düğümün kendi düzeyindeki değişken ve sabit bildirimlerini
+// verir; iç bloklardakiler o bloklara aittir
+func declarations(node ast.Node, f func(string, token.Token)) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.BlockStatement:
+			return n == node
+		case *ast.FunctionLiteral, *ast.TypeStatement:
+			return false
+		case *ast.AssignStatement:
+			if n.Declaration != nil {
+				assigned(n, f)
+			}
+		case *ast.ForInExpression:
+			declarations(n.Iterable, f)
+
+			if n.Alternative != nil {
+				declarations(n.Alternative, f)
+			}
+
+			return false
+		}
+
+		return true
+	})
+}
+
+// assigned atamanın yazdığı adları verir
+func assigned(as *ast.AssignStatement, f func(string, token.Token)) {
+	if as.Name != nil {
+		f(as.Name.Value, as.Name.Token)
+	}
+
+	for _, name := range as.Names {
+		if ident, ok := name.(*ast.Identifier); ok {
+			f(ident.Value, ident.Token)
+		}
+	}
+
+	if as.Pattern != nil {
+		patternNames(as.Pattern, f)
+	}
+}
+
+// patternNames kalıbın bağladığı adları verir
+func patternNames(pattern ast.Pattern, f func(string, token.Token)) {
+	ast.Inspect(pattern, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.BindingPattern:
+			f(n.Name, n.Token)
+		case *ast.TypePattern:
+			f(n.Binding, n.Token)
+		case *ast.ArrayPattern:
+			f(n.Rest, n.Token)
+		case *ast.HashPattern:
+			f(n.Rest, n.Token)
+		case ast.Expression:
+			// varsayılan değerler ve sabit kalıplar ad bağlamaz
+			return false
+		}
+
+		return true
+	})
+}
+
+func with(names map[string]bool, more ...string) map[string]bool {
+	m := map[string]bool{}
+	for name := range names {
+		m[name] = true
+	}
+
+	for _, name := range more {
+		m[name] = true
+	}
+
+	return m
+}
+
+// source kaynak ile yüklenen dosyanın adlarını kapsama ekler; dosya
+// bulunamazsa kapsamın adları bilinemez
+func (c *checker) source(call *ast.CallExpression) {
+	code, file, ok := c.readSource(call)
+	if !ok {
+		c.scope.dynamic = true
+		return
+	}
+
+	if c.sourced[file] {
+		return
+	}
+
+	c.sourced[file] = true
+
+	p := parser.New(lexer.NewFile(code, file))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		c.scope.dynamic = true
+		return
+	}
+
+	// yüklenen dosyanın kodu denetlenmez, yalnızca tanımladığı adlar alınır
+	c.collect(program, false)
+}
+
+func (c *checker) readSource(call *ast.CallExpression) (string, string, bool) {
+	if len(call.Arguments) != 1 {
+		return "", "", false
+	}
+
+	lit, ok := call.Arguments[0].(*ast.StringLiteral)
+	if !ok {
+		return "", "", false
+	}
+
+	if _, vars := util.StringVars(lit.Value); len(vars) > 0 {
+		return "", "", false
+	}
+
+	if strings.HasPrefix(lit.Value, "@") {
+		code, err := evaluator.Asset("stdlib/" + lit.Value[1:])
+		return string(code), lit.Value, err == nil
+	}
+
+	path, _ := util.ExpandPath(lit.Value)
+	candidates := []string{path}
+	if !filepath.IsAbs(path) {
+		// kaynak yolu çalışılan klasöre göredir; betikler çoğunlukla kendi
+		// klasörlerinden çalıştırılır
+		candidates = []string{filepath.Join(c.dir, path), path}
+	}
+
+	for _, file := range candidates {
+		if code, err := ioutil.ReadFile(file); err == nil {
+			return string(code), file, true
+		}
+	}
+
+	return "", "", false
+}
+
+func (c *checker) walk(node ast.Node) {
+	if node != nil {
+		ast.Inspect(node, c.visit)
+	}
+}
+
+func (c *checker) walkExpression(e ast.Expression) {
+	if e != nil {
+		c.walk(e)
+	}
+}
+
+func (c *checker) visit(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.Identifier:
+		c.use(n)
+		return false
+	case *ast.StringLiteral:
+		c.useStringVars(n.Value)
+	case *ast.CommandExpression:
+		c.useStringVars(n.Value)
+	case *ast.Program:
+		c.unreachable(n.Statements)
+	case *ast.BlockStatement:
+		c.unreachable(n.Statements)
+	case *ast.AssignStatement:
+		// atanan adlar okunmaz; d[0] = x ve h.ad = x ise d ve h'yi okur
+		for _, name := range n.Names {
+			if _, ok := name.(*ast.Identifier); !ok {
+				c.walkExpression(name)
+			}
+		}
+
+		if n.Index != nil {
+			c.walk(n.Index)
+		}
+
+		if n.Property != nil {
+			c.walk(n.Property)
+		}
+
+		if n.Pattern != nil {
+			c.walk(n.Pattern)
+		}
+
+		c.walkExpression(n.Value)
+		return false
+	case *ast.PropertyExpression:
+		// h.ad içindeki ad bir değişken değildir
+		c.walkExpression(n.Object)
+		return false
+	case *ast.MethodExpression:
+		c.walkExpression(n.Object)
+
+		for _, a := range n.Arguments {
+			c.walkExpression(a)
+		}
+
+		for _, k := range n.Keywords {
+			c.walkExpression(k.Value)
+		}
+
+		return false
+	case *ast.CallExpression:
+		c.checkBuiltinCall(n)
+	case *ast.FunctionLiteral:
+		c.function(n, "")
+		return false
+	case *ast.TypeStatement:
+		for _, field := range n.Fields {
+			c.walkExpression(field.Default)
+		}
+
+		for _, m := range n.Methods {
+			c.function(m, "bu")
+		}
+
+		return false
+	case *ast.TypePattern:
+		// Sayı, Yazı gibi yerleşik tip adları kapsamda değildir
+		if b := c.scope.lookup(n.TypeName); b != nil {
+			b.used = true
+		}
+	case *ast.IfExpression:
+		for _, scenario := range n.Scenarios {
+			c.walkExpression(scenario.Condition)
+			c.block(scenario.Consequence)
+		}
+
+		return false
+	case *ast.TryExpression:
+		c.block(n.Block)
+		c.block(n.Catch, n.Identifier)
+		c.block(n.Finally)
+		return false
+	case *ast.MatchExpression:
+		c.walkExpression(n.Subject)
+
+		for _, arm := range n.Arms {
+			c.arm(arm)
+		}
+
+		return false
+	case *ast.WhileExpression:
+		c.walkExpression(n.Condition)
+		c.loop(n.Consequence)
+		return false
+	case *ast.ForExpression:
+		// döngü değişkeni döngünün kendi kapsamındadır
+		c.open()
+		c.scope.declare(n.Identifier, n.Token, false)
+
+		if n.Starter != nil {
+			c.walk(n.Starter)
+		}
+
+		c.walkExpression(n.Condition)
+
+		if n.Closer != nil {
+			c.walk(n.Closer)
+		}
+
+		c.loop(n.Block)
+		c.close()
+		return false
+	case *ast.ForInExpression:
+		c.walkExpression(n.Iterable)
+		c.loop(n.Block, n.Key, n.Value)
+
+		// yoksa bloğu döngü bittikten sonra çalışır
+		if n.Alternative != nil {
+			c.walk(n.Alternative)
+		}
+
+		return false
+	case *ast.BreakStatement:
+		c.outsideLoop(n.Token)
+	case *ast.ContinueStatement:
+		c.outsideLoop(n.Token)
+	}
+
+	return true
+}
+
+func (c *checker) use(ident *ast.Identifier) {
+	if b := c.scope.lookup(ident.Value); b != nil {
+		b.used = true
+		return
+	}
+
+	if _, ok := evaluator.Fns[ident.Value]; ok || c.scope.isDynamic() {
+		return
+	}
+
+	c.report(Error, ident.Token, "'%s' tanımlı değil", ident.Value)
+}
+
+// useStringVars "merhaba $ad" gibi yazılardaki adları kullanılmış sayar;
+// tanımsız adlar yazıda boş kalır, hata değildir
+func (c *checker) useStringVars(s string) {
+	_, names := util.StringVars(s)
+
+	for _, name := range names {
+		if b := c.scope.lookup(name); b != nil {
+			b.used = true
+		}
+	}
+}
+
+// open bir blok kapsamı açar, close kapatıp bloğun kullanılmayan
+// değişkenlerini raporlar
+func (c *checker) open() {
+	c.scope = newBlockScope(c.scope)
+}
+
+func (c *checker) close() {
+	c.unused()
+	c.scope = c.scope.outer
+}
+
+// block bloğu kendi kapsamında denetler; names bloğa girerken tanımlanan
+// adlardır, örn. döngü değişkenleri ve yakala adı
+func (c *checker) block(body *ast.BlockStatement, names ...string) {
+	if body == nil {
+		return
+	}
+
+	c.open()
+
+	for _, name := range names {
+		c.scope.declare(name, body.Token, false)
+	}
+
+	c.declarations(body)
+	c.walk(body)
+	c.close()
+}
+
+// declarations bloğun kendi düzeyindeki bildirimleri kapsama ekler;
+// fonksiyonlardaki bildirimler kullanılmazsa raporlanır
+func (c *checker) declarations(body *ast.BlockStatement) {
+	local := c.scope.function().outer != nil
+
+	declarations(body, func(name string, tok token.Token) {
+		c.scope.declare(name, tok, local)
+	})
+}
+
+// arm eşle kolunu, kalıbın bağladığı adlarla birlikte kendi kapsamında
+// denetler
+func (c *checker) arm(arm *ast.MatchArm) {
+	c.open()
+
+	patternNames(arm.Pattern, func(name string, tok token.Token) {
+		c.scope.declare(name, tok, false)
+	})
+
+	c.walk(arm.Pattern)
+	c.walkExpression(arm.Guard)
+
+	if arm.Body != nil {
+		c.declarations(arm.Body)
+		c.walk(arm.Body)
+	}
+
+	c.close()
+}
+
+func (c *checker) loop(body *ast.BlockStatement, names ...string) {
+	c.loops++
+	c.block(body, names...)
+	c.loops--
+}
+
+func (c *checker) outsideLoop(tok token.Token) {
+	if c.loops == 0 {
+		c.report(Error, tok, "döngü dışında \"%s\" kullanılamaz", tok.Literal)
+	}
+}
+
+// function fonksiyonu kendi kapsamında denetler; receiver tip metotlarında
+// nesnenin adıdır
+func (c *checker) function(fl *ast.FunctionLiteral, receiver string) {
+	outer, loops := c.scope, c.loops
+	c.scope, c.loops = newScope(outer), 0
+
+	c.scope.declare(receiver, fl.Token, false)
+
+	for _, p := range fl.Parameters {
+		if p.Pattern != nil {
+			patternNames(p.Pattern, func(name string, tok token.Token) {
+				c.scope.declare(name, tok, false)
+			})
+		} else {
+			c.scope.declare(p.Value, p.Token, false)
+		}
+	}
+
+	c.collect(fl.Body, true)
+
+	for _, p := range fl.Parameters {
+		if p.Pattern != nil {
+			c.walk(p.Pattern)
+		}
+
+		c.walkExpression(p.Default)
+	}
+
+	c.walk(fl.Body)
+	c.unused()
+
+	c.scope, c.loops = outer, loops
+}
+
+// unused kapsamın kullanılmayan değişkenlerini raporlar
+func (c *checker) unused() {
+	if c.scope.function().dynamic {
+		return
+	}
+
+	for _, name := range c.scope.order {
+		b := c.scope.names[name]
+		if b.local && !b.used && !strings.HasPrefix(name, "_") {
+			c.report(Warning, b.tok, "'%s' değişkeni kullanılmıyor", name)
+		}
+	}
+}
+
+// checkBuiltinCall yerleşik fonksiyon çağrılarının argümanlarını Fns'teki
+// imzalara göre denetler. Metot çağrıları denetlenmez: h.it() haritanın
+// kendi fonksiyonunu da çağırıyor olabilir.
+func (c *checker) checkBuiltinCall(call *ast.CallExpression) {
+	ident, ok := call.Function.(*ast.Identifier)
+	if !ok || c.scope.lookup(ident.Value) != nil {
+		return
+	}
+
+	builtin, ok := evaluator.Fns[ident.Value]
+	if !ok {
+		return
+	}
+
+	if len(call.Keywords) > 0 {
+		c.report(Error, call.Keywords[0].Token, "yerleşik fonksiyonlar isimli argüman almaz")
+	}
+
+	arity := builtin.Arity
+	n := len(call.Arguments)
+	if arity == nil || (n >= arity.Min && (arity.Max == -1 || n <= arity.Max)) {
+		return
+	}
+
+	switch {
+	case arity.Min == arity.Max:
+		c.report(Error, ident.Token, "%s(...) için yanlış sayıda argüman: bulunan=%d, istenilen=%d", ident.Value, n, arity.Min)
+	case arity.Max == -1:
+		c.report(Error, ident.Token, "%s(...) için yanlış sayıda argüman: bulunan=%d, en az=%d", ident.Value, n, arity.Min)
+	default:
+		c.report(Error, ident.Token, "%s(...) için yanlış sayıda argüman: bulunan=%d, en az=%d, en fazla=%d", ident.Value, n, arity.Min, arity.Max)
+	}
+}
+
+// unreachable dön, dur ya da devam'dan sonra gelen ilk ifadeyi raporlar
+func (c *checker) unreachable(statements []ast.Statement) {
+	for i := 0; i+1 < len(statements); i++ {
+		if terminates(statements[i]) {
+			c.report(Warning, statementToken(statements[i+1]), "ulaşılamayan kod")
+			return
+		}
+	}
+}
+
+func terminates(st ast.Statement) bool {
+	switch st := st.(type) {
+	case *ast.ReturnStatement:
+		return true
+	case *ast.ExpressionStatement:
+		switch st.Expression.(type) {
+		case *ast.BreakStatement, *ast.ContinueStatement:
+			return true
+		}
+	}
+
+	return false
+}
+
+func statementToken(st ast.Statement) token.Token {
+	switch st := st.(type) {
+	case *ast.AssignStatement:
+		if st.Name != nil {
+			return st.Name.Token
+		}
+
+		return st.Token
+	case *ast.ExpressionStatement:
+		return st.Token
+	case *ast.ReturnStatement:
+		return st.Token
+	case *ast.BlockStatement:
+		return st.Token
+	case *ast.TypeStatement:
+		return st.Token
+	}
+
+	return token.Token{}
+}
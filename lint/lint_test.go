@@ -0,0 +1,105 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ankalang/anka/lexer"
+	"github.com/ankalang/anka/parser"
+)
+
+func check(t *testing.T, code string) []string {
+	t.Helper()
+
+	p := parser.New(lexer.NewFile(code, "test.ank"))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		t.Fatalf("ayrıştırıcı hatası: %s", strings.Join(p.Errors(), "; "))
+	}
+
+	diagnostics := []string{}
+	for _, d := range Check(program, "test.ank") {
+		diagnostics = append(diagnostics, d.String())
+	}
+
+	return diagnostics
+}
+
+func TestBlockScopes(t *testing.T) {
+	tests := []struct {
+		code string
+		want []string
+	}{
+		{"d = [1]\ndöngü j in d { eko(j) }\neko(j)", []string{"3:5: hata: 'j' tanımlı değil"}},
+		{"f g() {\n    eğer Doğru { değişken iç = 1 }\n    dön iç\n}\ng()", []string{
+			"2:27: uyarı: 'iç' değişkeni kullanılmıyor",
+			"3:9: hata: 'iç' tanımlı değil",
+		}},
+		{"döngü i = 0; i < 2; i = i + 1 { }\neko(i)", []string{"2:5: hata: 'i' tanımlı değil"}},
+		{"dene { hata(1) } yakala e { eko(e) }\neko(e)", []string{"2:5: hata: 'e' tanımlı değil"}},
+		{"eşle 3 { n eğer n > 1 => n, _ => 0 }\neko(n)", []string{"2:5: hata: 'n' tanımlı değil"}},
+		// düz atamalar fonksiyon kapsamına yazar
+		{"eğer Doğru { b = 5 }\neko(b)", []string{}},
+		{"f g() {\n    t = 0\n    döngü v in [1] { t = t + v }\n    dön t\n}\ng()", []string{}},
+		// bloktaki değişkene yapılan atama bloktaki değişkeni değiştirir
+		{"f g() {\n    eğer Doğru { değişken a = 1; a = 2; eko(a) }\n}\ng()", []string{}},
+	}
+
+	for _, tt := range tests {
+		got := check(t, tt.code)
+
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s\nbulunan: %q\nistenen: %q", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		code string
+		want []string
+	}{
+		// uyarı vermeyen program
+		{"f topla(a, b) { dön a + b }\nd = [1, 2]\ndöngü x in d { eko(topla(x, 1)) }\neko(uzunluk(d), yuvarla(1.5))", []string{}},
+		{"f g(_kullanılmayan) { dön 1 }\neko(g(1))", []string{}},
+		{"tip Kişi {\n    ad\n    f selam() { dön bu.ad }\n}\neko(Kişi(\"Ali\").selam())", []string{}},
+		// yerleşik fonksiyonların argüman sayısı
+		{"eko(uzunluk())", []string{"1:5: hata: uzunluk(...) için yanlış sayıda argüman: bulunan=0, istenilen=1"}},
+		{"eko(uzunluk([1], [2]))", []string{"1:5: hata: uzunluk(...) için yanlış sayıda argüman: bulunan=2, istenilen=1"}},
+		{"eko(fmt())", []string{"1:5: hata: fmt(...) için yanlış sayıda argüman: bulunan=0, en az=1"}},
+		{"eko(yuvarla(1, 2, 3, 4))", []string{"1:5: hata: yuvarla(...) için yanlış sayıda argüman: bulunan=4, en az=1, en fazla=3"}},
+		{"eko(uzunluk(x: [1]))", []string{
+			"1:5: hata: uzunluk(...) için yanlış sayıda argüman: bulunan=0, istenilen=1",
+			"1:13: hata: yerleşik fonksiyonlar isimli argüman almaz",
+		}},
+		// aynı adlı kullanıcı fonksiyonu yerleşik fonksiyonu gölgeler
+		{"f uzunluk() { dön 0 }\neko(uzunluk())", []string{}},
+		{"d = [1]\neko(d.uzunluk())", []string{}},
+		// kullanılmayan değişkenler
+		{"f g() {\n    a = 1\n    dön 2\n}\neko(g())", []string{"2:5: uyarı: 'a' değişkeni kullanılmıyor"}},
+		{"f g() {\n    değişken a = 1\n    sabit b = 2\n}\ng()", []string{
+			"2:14: uyarı: 'a' değişkeni kullanılmıyor",
+			"3:11: uyarı: 'b' değişkeni kullanılmıyor",
+		}},
+		// yalnızca fonksiyonlardaki değişkenler raporlanır
+		{"a = 1", []string{}},
+		// kullanılmayan parametreler uyarı vermez
+		{"f g(a) { dön 1 }\neko(g(1))", []string{}},
+		{"f g() {\n    a = 1\n    eval(\"eko(a)\")\n}\ng()", []string{}},
+		{"f g() {\n    a = 1\n    dön \"$a\"\n}\neko(g())", []string{}},
+		// tanımsız adlar
+		{"eko(olmayan)", []string{"1:5: hata: 'olmayan' tanımlı değil"}},
+		{"f g() { dön h() }\ng()", []string{"1:13: hata: 'h' tanımlı değil"}},
+		{"eko(g())\nf g() { dön 1 }", []string{}},
+		{"dur", []string{"1:1: hata: döngü dışında \"dur\" kullanılamaz"}},
+		{"f g() {\n    dön 1\n    eko(2)\n}\ng()", []string{"3:5: uyarı: ulaşılamayan kod"}},
+	}
+
+	for _, tt := range tests {
+		got := check(t, tt.code)
+
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s\nbulunan: %q\nistenen: %q", tt.code, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package lint
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/ankalang/anka/lexer"
+	"github.com/ankalang/anka/parser"
+)
+
+const usage = "kullanım: anka denetle [--katı] dosya.ank ..."
+
+// Run `anka denetle` komutunu çalıştırır ve çıkış kodunu döner. Raporlar
+// dosya:satır:sütun biçiminde yazılır; hata varsa 1 döner, --katı ile
+// uyarılar da 1 döndürür. Ayrıştırılamayan ya da okunamayan dosyalarda 99
+// döner.
+func Run(args []string) int {
+	strict := false
+	files := []string{}
+
+	for _, arg := range args {
+		if arg == "--katı" {
+			strict = true
+			continue
+		}
+
+		files = append(files, arg)
+	}
+
+	if len(files) == 0 {
+		fmt.Println(usage)
+		return 99
+	}
+
+	status := 0
+	for _, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s dosyası okunamadı: %s\n", file, err.Error())
+			status = 99
+			continue
+		}
+
+		p := parser.New(lexer.NewFile(string(b), file))
+		program := p.ParseProgram()
+
+		if len(p.Errors()) != 0 {
+			fmt.Fprintf(os.Stderr, "%s: Ayrıştırıcı hatası:\n", file)
+			for _, msg := range p.Errors() {
+				fmt.Fprintf(os.Stderr, "\t%s\n", msg)
+			}
+
+			status = 99
+			continue
+		}
+
+		for _, d := range Check(program, file) {
+			fmt.Printf("%s:%s\n", file, d)
+
+			if status == 0 && (d.Severity == Error || strict) {
+				status = 1
+			}
+		}
+	}
+
+	return status
+}
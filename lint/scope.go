@@ -0,0 +1,74 @@
+package lint
+
+import "github.com/ankalang/anka/token"
+
+// scope bir fonksiyonun, dosyanın ya da bir bloğun adlarıdır. Bloklar
+// object.NewBlockEnvironment gibidir: değişken ve sabit bildirimleri, döngü
+// değişkenleri ve yakala adı blokta kalır, düz atamalar en yakın fonksiyon
+// kapsamına yazar.
+type scope struct {
+	outer *scope
+	names map[string]*binding
+	// tanımlanma sırası, raporların sırası için
+	order []string
+	// eval ya da çözülemeyen bir kaynak çağrısı kapsama bilinmeyen adlar
+	// ekleyebilir
+	dynamic bool
+	block   bool
+}
+
+type binding struct {
+	tok token.Token
+	// fonksiyonun kendi değişkeni; kullanılmazsa raporlanır
+	local bool
+	used  bool
+}
+
+func newScope(outer *scope) *scope {
+	return &scope{outer: outer, names: map[string]*binding{}}
+}
+
+func newBlockScope(outer *scope) *scope {
+	s := newScope(outer)
+	s.block = true
+	return s
+}
+
+// function blokların dışındaki en yakın fonksiyon ya da dosya kapsamını döner
+func (s *scope) function() *scope {
+	sc := s
+	for sc.block && sc.outer != nil {
+		sc = sc.outer
+	}
+
+	return sc
+}
+
+func (s *scope) declare(name string, tok token.Token, local bool) {
+	if name == "" || s.names[name] != nil {
+		return
+	}
+
+	s.names[name] = &binding{tok: tok, local: local}
+	s.order = append(s.order, name)
+}
+
+func (s *scope) lookup(name string) *binding {
+	for sc := s; sc != nil; sc = sc.outer {
+		if b, ok := sc.names[name]; ok {
+			return b
+		}
+	}
+
+	return nil
+}
+
+func (s *scope) isDynamic() bool {
+	for sc := s; sc != nil; sc = sc.outer {
+		if sc.dynamic {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/iscosmos/anka/format"
 	"github.com/iscosmos/anka/install"
+	"github.com/iscosmos/anka/lint"
 	"github.com/iscosmos/anka/repl"
 	"github.com/iscosmos/anka/util"
 )
@@ -30,6 +32,14 @@ func main() {
 		return
 	}
 
+	if len(args) >= 2 && (args[1] == "biçimle" || args[1] == "fmt") {
+		os.Exit(format.Run(args[2:]))
+	}
+
+	if len(args) >= 2 && args[1] == "denetle" {
+		os.Exit(lint.Run(args[2:]))
+	}
+
 	// begin the REPL
 	repl.BeginRepl(args, Version)
 }
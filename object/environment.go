@@ -1,8 +1,12 @@
 package object
 
 import (
+	"fmt"
 	"io"
 	"sort"
+	"sync"
+
+	"github.com/ankalang/anka/token"
 )
 
 
@@ -11,6 +15,7 @@ import (
 
 func NewEnclosedEnvironment(outer *Environment, args []Object) *Environment {
 	env := NewEnvironment(outer.Writer, outer.Dir, outer.Version)
+	env.Runtime = outer.Runtime
 	env.outer = outer
 	env.CurrentArgs = args
 	return env
@@ -20,6 +25,37 @@ func NewEnclosedEnvironment(outer *Environment, args []Object) *Environment {
 
 
 
+// NewBlockEnvironment eğer ve döngü gövdeleri için bir blok kapsamı açar;
+// değişken ve sabit bildirimleri blokta kalır, düz atamalar dışarıya yazar
+func NewBlockEnvironment(outer *Environment) *Environment {
+	return &Environment{
+		store:       make(map[string]Object),
+		outer:       outer,
+		block:       true,
+		CurrentArgs: outer.CurrentArgs,
+		Writer:      outer.Writer,
+		Dir:         outer.Dir,
+		Version:     outer.Version,
+		Runtime:     outer.Runtime,
+	}
+}
+
+// NewCallEnvironment outer'ın değişkenlerini gören ve etkin çağrısı call olan
+// bir ortam açar; derlenmiş kod yerleşik fonksiyonları çağırırken çağrı
+// derinliğini böyle taşır
+func NewCallEnvironment(outer *Environment, call *Call) *Environment {
+	return &Environment{
+		store:       make(map[string]Object),
+		outer:       outer,
+		CurrentArgs: outer.CurrentArgs,
+		Writer:      outer.Writer,
+		Dir:         outer.Dir,
+		Version:     outer.Version,
+		Runtime:     outer.Runtime,
+		Call:        call,
+	}
+}
+
 func NewEnvironment(w io.Writer, dir string, version string) *Environment {
 	s := make(map[string]Object)
 	
@@ -35,6 +71,15 @@ func NewEnvironment(w io.Writer, dir string, version string) *Environment {
 
 type Environment struct {
 	store map[string]Object
+	// sabit ile tanımlanmış adlar
+	constants map[string]bool
+	// eğer ve döngü gövdeleri blok kapsamıdır, fonksiyon ortamları değildir
+	block bool
+	// blok kapsamında oluşturulan bir fonksiyon kapsamı yakaladıysa kapsam
+	// boşaltılıp yeniden kullanılamaz
+	captured bool
+	// eşzamanlı görevler aynı ortamı paylaşabilir
+	mu    sync.RWMutex
 	
 	
 	
@@ -61,11 +106,41 @@ type Environment struct {
 	Dir string
 	
 	Version string
+
+	// ortamı çalıştıran yorumlayıcının durumu (evaluator.Interpreter)
+	Runtime interface{}
+
+	// fonksiyon ortamlarında ortamı açan çağrı
+	Call *Call
+}
+
+// Call çalışmakta olan bir fonksiyon çağrısıdır; Caller onu çağıran çağrıdır,
+// dosya düzeyinden yapılan çağrılarda nil'dir
+type Call struct {
+	Function string
+	Token    token.Token
+	Caller   *Call
+	Depth    int
+}
+
+// ActiveCall ortamın içinde bulunduğu fonksiyon çağrısını döner; blok
+// kapsamları çağrıyı bulundukları fonksiyondan alır
+func (e *Environment) ActiveCall() *Call {
+	for env := e; env != nil; env = env.outer {
+		if !env.block {
+			return env.Call
+		}
+	}
+
+	return nil
 }
 
 
 func (e *Environment) Get(name string) (Object, bool) {
+	e.mu.RLock()
 	obj, ok := e.store[name]
+	e.mu.RUnlock()
+
 	if !ok && e.outer != nil {
 		obj, ok = e.outer.Get(name)
 	}
@@ -75,10 +150,12 @@ func (e *Environment) Get(name string) (Object, bool) {
 
 
 func (e *Environment) GetKeys() []string {
+	e.mu.RLock()
 	keys := make([]string, 0, len(e.store))
 	for k := range e.store {
 		keys = append(keys, k)
 	}
+	e.mu.RUnlock()
 
 	sort.Strings(keys)
 
@@ -87,11 +164,96 @@ func (e *Environment) GetKeys() []string {
 
 
 func (e *Environment) Set(name string, val Object) Object {
+	e.mu.Lock()
 	e.store[name] = val
+	e.mu.Unlock()
 	return val
 }
 
 
+// Declare adı bu kapsamda tanımlar, dıştaki aynı adlı değişkeni gölgeler
+func (e *Environment) Declare(name string, val Object, constant bool) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.constants[name] {
+		return fmt.Errorf("'%s' sabittir, yeniden tanımlanamaz", name)
+	}
+
+	if constant {
+		if e.constants == nil {
+			e.constants = map[string]bool{}
+		}
+
+		e.constants[name] = true
+	}
+
+	e.store[name] = val
+	return nil
+}
+
+// Assign adın tanımlandığı kapsamdaki değeri günceller; ad hiçbir kapsamda
+// yoksa en yakın fonksiyon kapsamında tanımlanır
+func (e *Environment) Assign(name string, val Object) error {
+	for env := e; env != nil; env = env.outer {
+		env.mu.Lock()
+		if _, ok := env.store[name]; ok {
+			defer env.mu.Unlock()
+
+			if env.constants[name] {
+				return fmt.Errorf("'%s' sabittir, değeri değiştirilemez", name)
+			}
+
+			env.store[name] = val
+			return nil
+		}
+		env.mu.Unlock()
+	}
+
+	e.Scope().Set(name, val)
+	return nil
+}
+
+// Capture ortamın bir fonksiyona bağlandığını işaretler; ortamın içinde
+// bulunduğu blok kapsamları da fonksiyonla birlikte yakalanır
+func (e *Environment) Capture() {
+	for env := e; env != nil && env.block; env = env.outer {
+		env.mu.Lock()
+		env.captured = true
+		env.mu.Unlock()
+	}
+}
+
+// Reset blok kapsamını döngünün sonraki turu için boşaltır; kapsamı bir
+// fonksiyon yakaladıysa kapsama dokunmaz ve false döner
+func (e *Environment) Reset() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.captured {
+		return false
+	}
+
+	for name := range e.store {
+		delete(e.store, name)
+	}
+	e.constants = nil
+
+	return true
+}
+
+// Scope blokların dışındaki en yakın fonksiyon ya da dosya kapsamını döner
+func (e *Environment) Scope() *Environment {
+	env := e
+	for env.block && env.outer != nil {
+		env = env.outer
+	}
+
+	return env
+}
+
 func (e *Environment) Delete(name string) {
+	e.mu.Lock()
 	delete(e.store, name)
+	e.mu.Unlock()
 }
@@ -2,14 +2,19 @@ package object
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
+	"math"
+	"math/big"
 	"os/exec"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/ankalang/anka/ast"
+	"github.com/ankalang/anka/code"
 	"github.com/ankalang/anka/token"
 )
 
@@ -22,8 +27,10 @@ const (
 	ERROR_OBJ = "ERROR"
 
 	NUMBER_OBJ  = "NUMBER"
+	DECIMAL_OBJ = "DECIMAL"
 	BOOLEAN_OBJ = "BOOLEAN"
 	STRING_OBJ  = "STRING"
+	BYTES_OBJ   = "BYTES"
 
 	RETURN_VALUE_OBJ = "RETURN_VALUE"
 
@@ -31,8 +38,18 @@ const (
 	FUNCTION_OBJ = "FUNCTION"
 	BUILTIN_OBJ  = "BUILTIN"
 
+	TYPE_OBJ     = "TYPE"
+	INSTANCE_OBJ = "INSTANCE"
+
 	ARRAY_OBJ = "ARRAY"
 	HASH_OBJ  = "HASH"
+	SET_OBJ   = "SET"
+
+	TIME_OBJ = "TIME"
+
+	TASK_OBJ      = "TASK"
+	CHANNEL_OBJ   = "CHANNEL"
+	WAITGROUP_OBJ = "WAITGROUP"
 )
 
 var (
@@ -60,7 +77,31 @@ type Object interface {
 
 
 func GenerateEqualityString(o Object) string {
-	return fmt.Sprintf("%s:%s", o.Type(), o.Inspect())
+	return fmt.Sprintf("%s:%s", o.Type(), equalityValue(o))
+}
+
+
+
+func equalityValue(o Object) string {
+	switch o := o.(type) {
+	case *Hash:
+		pairs := []string{}
+		for _, pair := range o.OrderedPairs() {
+			pairs = append(pairs, GenerateEqualityString(pair.Key)+": "+GenerateEqualityString(pair.Value))
+		}
+		sort.Strings(pairs)
+
+		return "{" + strings.Join(pairs, ", ") + "}"
+	case *Array:
+		elements := []string{}
+		for _, e := range o.Elements {
+			elements = append(elements, GenerateEqualityString(e))
+		}
+
+		return "[" + strings.Join(elements, ", ") + "]"
+	default:
+		return o.Inspect()
+	}
 }
 
 
@@ -68,30 +109,197 @@ func Equal(obj1 Object, obj2 Object) bool {
 	return GenerateEqualityString(obj1) == GenerateEqualityString(obj2)
 }
 
+
+
+func jsonString(s string) string {
+	var out strings.Builder
+	out.WriteByte('"')
+
+	for _, r := range s {
+		switch r {
+		case '"':
+			out.WriteString(`\"`)
+		case '\\':
+			out.WriteString(`\\`)
+		case '\n':
+			out.WriteString(`\n`)
+		case '\r':
+			out.WriteString(`\r`)
+		case '\t':
+			out.WriteString(`\t`)
+		case '\b':
+			out.WriteString(`\b`)
+		case '\f':
+			out.WriteString(`\f`)
+		case '\u2028', '\u2029':
+			fmt.Fprintf(&out, `\u%04x`, r)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&out, `\u%04x`, r)
+			} else {
+				out.WriteRune(r)
+			}
+		}
+	}
+
+	out.WriteByte('"')
+	return out.String()
+}
+
+// Iterable döngüde dolaşılabilen değerlerdir. Her döngü Iterator ile kendi
+// imlecini alır; iç içe ya da eşzamanlı döngüler aynı değeri birbirini
+// etkilemeden dolaşır. İmleç nil anahtar döndüğünde dolaşım biter.
 type Iterable interface {
-	Next() (Object, Object)
-	Reset()
+	Iterator() func() (Object, Object)
 }
 
+// Number hem ondalıklı sayıları hem de tamsayıları taşır. Tamsayılarda kesin
+// değer Small'da, int64'e sığmıyorsa Big'de tutulur; Value her zaman yaklaşık
+// float64 karşılığıdır, böylece yalnızca Value okuyan kod çalışmaya devam eder.
 type Number struct {
-	Token token.Token
-	Value float64
+	Token   token.Token
+	Value   float64
+	Integer bool
+	Small   int64
+	Big     *big.Int
+}
+
+func NewInteger(tok token.Token, v int64) *Number {
+	return &Number{Token: tok, Value: float64(v), Integer: true, Small: v}
+}
+
+// NewBigInteger v'yi sahiplenir, çağıran v'yi daha sonra değiştirmemeli
+func NewBigInteger(tok token.Token, v *big.Int) *Number {
+	if v.IsInt64() {
+		return NewInteger(tok, v.Int64())
+	}
+
+	f, _ := new(big.Float).SetInt(v).Float64()
+	return &Number{Token: tok, Value: f, Integer: true, Big: v}
+}
+
+// BigInt tamsayının kesin değerini döner, dönen değer değiştirilmemeli
+func (n *Number) BigInt() *big.Int {
+	if n.Big != nil {
+		return n.Big
+	}
+
+	if n.Integer {
+		return big.NewInt(n.Small)
+	}
+
+	i, _ := big.NewFloat(n.Value).Int(nil)
+	return i
 }
 
 func (n *Number) Type() ObjectType { return NUMBER_OBJ }
 
 func (n *Number) Inspect() string {
-	if n.IsInt() {
-		return fmt.Sprintf("%d", int64(n.Value))
+	if n.Big != nil {
+		return n.Big.String()
+	}
+
+	if n.Integer {
+		return strconv.FormatInt(n.Small, 10)
 	}
+
+	if n.Value == math.Trunc(n.Value) && math.Abs(n.Value) < math.MaxInt64 {
+		return strconv.FormatInt(int64(n.Value), 10)
+	}
+
 	return strconv.FormatFloat(n.Value, 'f', -1, 64)
 }
 func (n *Number) IsInt() bool {
-	return n.Value == float64(int64(n.Value))
+	return n.Integer || n.Value == math.Trunc(n.Value)
+}
+func (n *Number) Json() string {
+	if math.IsNaN(n.Value) || math.IsInf(n.Value, 0) {
+		return "null"
+	}
+
+	return n.Inspect()
 }
-func (n *Number) Json() string       { return n.Inspect() }
 func (n *Number) ZeroValue() float64 { return float64(0) }
-func (n *Number) Int() int           { return int(n.Value) }
+func (n *Number) HashKey() HashKey {
+	// 1 ve 1.0 aynı anahtara düşer, -0 da 0 olarak saklanır
+	if n.Integer {
+		return HashKey{Type: n.Type(), Value: n.Inspect()}
+	}
+
+	v := n.Value
+	if v == 0 {
+		v = 0
+	}
+
+	// 2 ** 64 ile 2.0 ** 64 de aynı anahtara düşsün diye tam değer kesin yazılır
+	if v == math.Trunc(v) {
+		return HashKey{Type: n.Type(), Value: strconv.FormatFloat(v, 'f', 0, 64)}
+	}
+
+	return HashKey{Type: n.Type(), Value: strconv.FormatFloat(v, 'g', -1, 64)}
+}
+func (n *Number) Int() int {
+	if n.Integer && n.Big == nil {
+		return int(n.Small)
+	}
+
+	return int(n.Value)
+}
+
+// Decimal para hesapları gibi kesinlik gereken yerler için onluk tabanda bir
+// sayıdır: değeri Unscaled / 10^Scale'dir, 12.30 için Unscaled 1230, Scale 2.
+// Scale hiçbir zaman negatif olmaz.
+type Decimal struct {
+	Token    token.Token
+	Unscaled *big.Int
+	Scale    int
+}
+
+func (d *Decimal) Type() ObjectType { return DECIMAL_OBJ }
+
+func (d *Decimal) Inspect() string {
+	digits := new(big.Int).Abs(d.Unscaled).String()
+	sign := ""
+
+	if d.Unscaled.Sign() < 0 {
+		sign = "-"
+	}
+
+	if d.Scale == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= d.Scale {
+		digits = strings.Repeat("0", d.Scale-len(digits)+1) + digits
+	}
+
+	point := len(digits) - d.Scale
+	return sign + digits[:point] + "." + digits[point:]
+}
+func (d *Decimal) Json() string { return d.Inspect() }
+
+// 1.5 ve 1.50 aynı anahtara düşer. Bir sayıya eşit olan ondalıklar (== ile)
+// o sayının anahtarına düşer: h[1.0d] ile h[1], h[0.5d] ile h[0.5] aynıdır.
+func (d *Decimal) HashKey() HashKey {
+	r := d.Rat()
+	if r.IsInt() {
+		return HashKey{Type: NUMBER_OBJ, Value: r.Num().String()}
+	}
+
+	// sayılar ondalığa en kısa yazılışlarıyla çevrilir; ondalık bu yazılışa
+	// eşitse sayıyla eşittir
+	if f, _ := r.Float64(); !math.IsInf(f, 0) {
+		if back, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64)); ok && back.Cmp(r) == 0 {
+			return HashKey{Type: NUMBER_OBJ, Value: strconv.FormatFloat(f, 'g', -1, 64)}
+		}
+	}
+
+	return HashKey{Type: d.Type(), Value: r.RatString()}
+}
+
+func (d *Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.Unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.Scale)), nil))
+}
 
 type Boolean struct {
 	Token token.Token
@@ -101,6 +309,9 @@ type Boolean struct {
 func (b *Boolean) Type() ObjectType { return BOOLEAN_OBJ }
 func (b *Boolean) Inspect() string  { return fmt.Sprintf("%t", b.Value) }
 func (b *Boolean) Json() string     { return b.Inspect() }
+func (b *Boolean) HashKey() HashKey {
+	return HashKey{Type: b.Type(), Value: b.Inspect()}
+}
 
 type Null struct {
 	Token token.Token
@@ -109,6 +320,132 @@ type Null struct {
 func (n *Null) Type() ObjectType { return NULL_OBJ }
 func (n *Null) Inspect() string  { return "null" }
 func (n *Null) Json() string     { return n.Inspect() }
+func (n *Null) HashKey() HashKey {
+	return HashKey{Type: n.Type(), Value: n.Inspect()}
+}
+
+type Time struct {
+	Token token.Token
+	Value time.Time
+}
+
+func (t *Time) Type() ObjectType { return TIME_OBJ }
+func (t *Time) Inspect() string  { return t.Value.Format(time.RFC3339Nano) }
+func (t *Time) Json() string     { return jsonString(t.Inspect()) }
+
+type Task struct {
+	Token  token.Token
+	Done   chan struct{}
+	Result Object
+}
+
+func (t *Task) Type() ObjectType { return TASK_OBJ }
+func (t *Task) Inspect() string {
+	select {
+	case <-t.Done:
+		return "görev(bitti)"
+	default:
+		return "görev(çalışıyor)"
+	}
+}
+func (t *Task) Json() string { return t.Inspect() }
+
+type Channel struct {
+	Token  token.Token
+	Value  chan Object
+	closed bool
+	mu     sync.Mutex
+}
+
+func (c *Channel) Type() ObjectType { return CHANNEL_OBJ }
+func (c *Channel) Inspect() string  { return fmt.Sprintf("kanal(%d)", cap(c.Value)) }
+func (c *Channel) Json() string     { return c.Inspect() }
+
+// Send kanal kapalıysa false döner
+func (c *Channel) Send(v Object) (sent bool) {
+	defer func() {
+		if recover() != nil {
+			sent = false
+		}
+	}()
+
+	c.Value <- v
+	return true
+}
+
+// Close kanal zaten kapalıysa false döner
+func (c *Channel) Close() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return false
+	}
+
+	close(c.Value)
+	c.closed = true
+	return true
+}
+
+// Iterator kanal kapanana kadar gelen değerleri sırayla alır
+func (c *Channel) Iterator() func() (Object, Object) {
+	position := 0
+
+	return func() (Object, Object) {
+		v, ok := <-c.Value
+		if !ok {
+			return nil, nil
+		}
+
+		position++
+		return NewInteger(token.Token{}, int64(position-1)), v
+	}
+}
+
+// WaitGroup sayacı sync.WaitGroup gibi çalışır ama sıfırın altına inmeye
+// çalışınca panik yerine false döner
+type WaitGroup struct {
+	Token token.Token
+	mu    sync.Mutex
+	zero  *sync.Cond
+	count int
+}
+
+// Add sayacı n kadar değiştirir; sayaç eksiye düşecekse değiştirmez ve false
+// döner
+func (w *WaitGroup) Add(n int) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.count+n < 0 {
+		return false
+	}
+
+	w.count += n
+	if w.count == 0 && w.zero != nil {
+		w.zero.Broadcast()
+	}
+
+	return true
+}
+
+// Wait sayaç sıfırlanana kadar bekler
+func (w *WaitGroup) Wait() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.zero == nil {
+		w.zero = sync.NewCond(&w.mu)
+	}
+
+	for w.count > 0 {
+		w.zero.Wait()
+	}
+}
+
+func (w *WaitGroup) Type() ObjectType { return WAITGROUP_OBJ }
+func (w *WaitGroup) Inspect() string  { return "bekleme_grubu" }
+func (w *WaitGroup) Json() string     { return w.Inspect() }
 
 type ReturnValue struct {
 	Token token.Token
@@ -121,12 +458,80 @@ func (rv *ReturnValue) Json() string     { return rv.Inspect() }
 
 type Error struct {
 	Message string
+	Text    string
+	File    string
+	Line    int
+	Column  int
+	// hatanın çıkarken geçtiği çağrılar, en içteki önce
+	Stack []Frame
+	// hata(harita) ile verilen harita; alanları yakala'daki hataya eklenir
+	Data *Hash
 }
 
 func (e *Error) Type() ObjectType { return ERROR_OBJ }
 func (e *Error) Inspect() string  { return "\033[31mHata: \x1B[38;2;52;34;189m" + e.Message }
 func (e *Error) Json() string     { return e.Inspect() }
 
+// Frame bir fonksiyon ya da modül çağrısıdır; konum çağrının yapıldığı yerdir
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+	Column   int
+}
+
+func (f Frame) String() string {
+	if f.Line == 0 {
+		return f.Function
+	}
+
+	if f.File == "" {
+		return fmt.Sprintf("%s %d:%d", f.Function, f.Line, f.Column)
+	}
+
+	return fmt.Sprintf("%s %s:%d:%d", f.Function, f.File, f.Line, f.Column)
+}
+
+// hata yığınında gösterilen en fazla satır
+const maxTracebackLines = 20
+
+// Traceback hatanın çağrı yığınını en içteki çağrıdan başlayarak yazar; art
+// arda aynı yerden yapılan çağrılar tek satırda toplanır. Yığın boşsa boş
+// döner.
+func (e *Error) Traceback() string {
+	if len(e.Stack) == 0 {
+		return ""
+	}
+
+	var out bytes.Buffer
+	out.WriteString("çağrı yığını:\n")
+
+	lines := 0
+	for i := 0; i < len(e.Stack); i++ {
+		if lines == maxTracebackLines {
+			fmt.Fprintf(&out, "  ... %d çağrı daha\n", len(e.Stack)-i)
+			break
+		}
+
+		frame := e.Stack[i]
+		repeat := 1
+		for i+1 < len(e.Stack) && e.Stack[i+1] == frame {
+			i++
+			repeat++
+		}
+
+		out.WriteString("  " + frame.String())
+		if repeat > 1 {
+			fmt.Fprintf(&out, " (%d kez)", repeat)
+		}
+
+		out.WriteString("\n")
+		lines++
+	}
+
+	return out.String()
+}
+
 type BreakError struct {
 	Error
 }
@@ -145,30 +550,140 @@ type Function struct {
 }
 
 func (f *Function) Type() ObjectType { return FUNCTION_OBJ }
-func (f *Function) Inspect() string {
+func (f *Function) Inspect() string  { return inspectFunction(f.Name, f.Parameters, f.Body) }
+func (f *Function) Json() string     { return f.Inspect() }
+
+func inspectFunction(name string, parameters []*ast.Parameter, body *ast.BlockStatement) string {
 	var out bytes.Buffer
 
 	params := []string{}
-	for _, p := range f.Parameters {
+	for _, p := range parameters {
 		params = append(params, p.String())
 	}
 
 	out.WriteString("f")
 
-	if f.Name != "" {
-		out.WriteString(" " + f.Name)
+	if name != "" {
+		out.WriteString(" " + name)
 	}
 
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
 	out.WriteString(") {")
-	out.WriteString(f.Body.String())
+	out.WriteString(body.String())
 	out.WriteString("}")
 
 	return out.String()
 }
 
-func (f *Function) Json() string { return f.Inspect() }
+// KeywordArgument çağrıda ad: değer biçiminde verilmiş bir argümandır
+type KeywordArgument struct {
+	Token token.Token
+	Name  string
+	Value Object
+}
+
+// CompiledFunction bayt koduna derlenmiş bir fonksiyondur; dosyanın kendisi
+// de Node'u olmayan bir fonksiyon olarak derlenir
+type CompiledFunction struct {
+	Instructions code.Instructions
+	// komutların hata konumları için başvurduğu simgeler
+	Tokens []token.Token
+	// isimli argümanlı çağrıların adları
+	Keywords  [][]*ast.KeywordArgument
+	NumLocals int
+	NumCells  int
+	// kapanış oluşturulurken dış fonksiyondan yakalanan hücreler
+	Free []FreeVariable
+	Node *ast.FunctionLiteral
+}
+
+// FreeVariable yakalanan hücrenin dış fonksiyondaki yeridir: dış fonksiyonun
+// kendi hücresi ya da onun da yakaladığı bir hücre
+type FreeVariable struct {
+	Cell  bool
+	Index int
+}
+
+// Cell birden fazla kapanışın paylaştığı değişkendir; eşzamanlı görevler aynı
+// hücreyi paylaşabilir
+type Cell struct {
+	mu    sync.RWMutex
+	value Object
+}
+
+func NewCell(value Object) *Cell {
+	return &Cell{value: value}
+}
+
+// Get hücrenin değerini döner; nil değişkenin henüz atanmadığını gösterir
+func (c *Cell) Get() Object {
+	c.mu.RLock()
+	value := c.value
+	c.mu.RUnlock()
+
+	return value
+}
+
+func (c *Cell) Set(value Object) {
+	c.mu.Lock()
+	c.value = value
+	c.mu.Unlock()
+}
+
+// ClosureRunner kapanışları çalıştıran sanal makinedir; yerleşik fonksiyonlar
+// ve ağaç yorumlayıcısı kapanışları bunun üzerinden çağırır. env çağrının
+// yapıldığı ortamdır, çağrı derinliği onun etkin çağrısından devam eder.
+type ClosureRunner interface {
+	RunClosure(tok token.Token, cl *Closure, env *Environment, args []Object, keywords []KeywordArgument) Object
+}
+
+type Closure struct {
+	Fn      *CompiledFunction
+	Free    []*Cell
+	Machine ClosureRunner
+}
+
+func (c *Closure) Type() ObjectType { return FUNCTION_OBJ }
+func (c *Closure) Inspect() string {
+	return inspectFunction(c.Fn.Node.Name, c.Fn.Node.Parameters, c.Fn.Node.Body)
+}
+func (c *Closure) Json() string { return c.Inspect() }
+
+// TypeDef "tip Kişi { ... }" ile tanımlanan kullanıcı tipidir, çağrıldığında
+// yeni bir Instance oluşturur
+type TypeDef struct {
+	Token   token.Token
+	Name    string
+	Fields  []*ast.TypeField
+	Methods map[string]*Function
+	Env     *Environment
+}
+
+func (t *TypeDef) Type() ObjectType { return TYPE_OBJ }
+func (t *TypeDef) Inspect() string  { return "tip " + t.Name }
+func (t *TypeDef) Json() string     { return jsonString(t.Inspect()) }
+
+func (t *TypeDef) HasField(name string) bool {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Instance bir kullanıcı tipinin değeridir, alanları tanımlandıkları sırayla tutulur
+type Instance struct {
+	Token  token.Token
+	Of     *TypeDef
+	Fields *Hash
+}
+
+func (i *Instance) Type() ObjectType { return INSTANCE_OBJ }
+func (i *Instance) Inspect() string  { return i.Of.Name + i.Fields.Inspect() }
+func (i *Instance) Json() string     { return i.Fields.Json() }
 
 type String struct {
 	Token  token.Token
@@ -183,7 +698,7 @@ type String struct {
 
 func (s *String) Type() ObjectType  { return STRING_OBJ }
 func (s *String) Inspect() string   { return s.Value }
-func (s *String) Json() string      { return `"` + strings.ReplaceAll(s.Inspect(), `"`, `\"`) + `"` }
+func (s *String) Json() string      { return jsonString(s.Value) }
 func (s *String) ZeroValue() string { return "" }
 func (s *String) HashKey() HashKey {
 	return HashKey{Type: s.Type(), Value: s.Value}
@@ -240,38 +755,146 @@ func (s *String) SetCmdResult(Ok *Boolean) {
 	s.Done = TRUE
 }
 
+// Bytes metin olmayan ikili verileri taşır, değeri değiştirilmez
+type Bytes struct {
+	Token token.Token
+	Value []byte
+}
+
+func (b *Bytes) Type() ObjectType { return BYTES_OBJ }
+func (b *Bytes) Inspect() string  { return fmt.Sprintf("bayt(%q)", b.Value) }
+
+// JSON'da baytlar base64 metni olarak yazılır
+func (b *Bytes) Json() string {
+	return jsonString(base64.StdEncoding.EncodeToString(b.Value))
+}
+func (b *Bytes) HashKey() HashKey {
+	return HashKey{Type: b.Type(), Value: string(b.Value)}
+}
+func (b *Bytes) Iterator() func() (Object, Object) {
+	position := 0
+
+	return func() (Object, Object) {
+		if position < len(b.Value) {
+			position++
+			return NewInteger(token.Token{}, int64(position-1)), NewInteger(token.Token{}, int64(b.Value[position-1]))
+		}
+
+		return nil, nil
+	}
+}
+
 type Builtin struct {
 	Token    token.Token
 	Fn       BuiltinFunction
-	Next     func() (Object, Object)
+	Next     func(env *Environment) (Object, Object)
 	Types    []string
 	Iterable bool
+	// alabileceği argüman sayısı, bkz. Arity
+	Arity *Arity
+	// Next ile dolaşılan döngü bittiğinde ya da yarıda kaldığında çağrılır
+	Close func()
+}
+
+// Arity yerleşik fonksiyonun en az ve en fazla argüman sayısıdır; metot
+// olarak çağrıldığında nesnenin kendisi de argüman sayılır. Max -1 ise
+// argüman sayısı sınırsızdır.
+type Arity struct {
+	Min int
+	Max int
 }
 
 func (b *Builtin) Type() ObjectType { return BUILTIN_OBJ }
 func (b *Builtin) Inspect() string  { return "Fonksiyon" }
 func (b *Builtin) Json() string     { return b.Inspect() }
 
+// Dizinin elemanlarını değiştiren işlemler (Push, Pop, Shift, SetAt) kilit
+// altında yapılır, böylece görevler arasında paylaşılan bir diziye aynı anda
+// eleman eklenebilir.
 type Array struct {
 	Token    token.Token
 	Elements []Object
 
 	IsCurrentArgs bool
-	position      int
+
+	mu sync.Mutex
 }
 
 func (ao *Array) Type() ObjectType { return ARRAY_OBJ }
-func (ao *Array) Next() (Object, Object) {
-	position := ao.position
-	if len(ao.Elements) > position {
-		ao.position = position + 1
-		return &Number{Value: float64(position)}, ao.Elements[position]
+
+func (ao *Array) Push(values ...Object) {
+	ao.mu.Lock()
+	defer ao.mu.Unlock()
+
+	ao.Elements = append(ao.Elements, values...)
+}
+
+// Pop son elemanı çıkarır, dizi boşsa false döner
+func (ao *Array) Pop() (Object, bool) {
+	ao.mu.Lock()
+	defer ao.mu.Unlock()
+
+	if len(ao.Elements) == 0 {
+		return nil, false
+	}
+
+	last := ao.Elements[len(ao.Elements)-1]
+	ao.Elements = ao.Elements[:len(ao.Elements)-1]
+
+	return last, true
+}
+
+// Shift ilk elemanı çıkarır, dizi boşsa false döner
+func (ao *Array) Shift() (Object, bool) {
+	ao.mu.Lock()
+	defer ao.mu.Unlock()
+
+	if len(ao.Elements) == 0 {
+		return nil, false
+	}
+
+	first := ao.Elements[0]
+	ao.Elements = append(ao.Elements[:0], ao.Elements[1:]...)
+
+	return first, true
+}
+
+// SetAt dizinin sonundan ötedeki bir dizine yazılırsa aradaki elemanlar
+// fill ile doldurulur
+func (ao *Array) SetAt(index int, value Object, fill Object) {
+	ao.mu.Lock()
+	defer ao.mu.Unlock()
+
+	for len(ao.Elements) <= index {
+		ao.Elements = append(ao.Elements, fill)
+	}
+
+	ao.Elements[index] = value
+}
+
+func (ao *Array) at(index int) (Object, bool) {
+	ao.mu.Lock()
+	defer ao.mu.Unlock()
+
+	if index < len(ao.Elements) {
+		return ao.Elements[index], true
 	}
 
-	return nil, nil
+	return nil, false
 }
-func (ao *Array) Reset() {
-	ao.position = 0
+
+// Iterator döngü sırasında diziye eklenen elemanları da dolaşır
+func (ao *Array) Iterator() func() (Object, Object) {
+	position := 0
+
+	return func() (Object, Object) {
+		if e, ok := ao.at(position); ok {
+			position++
+			return NewInteger(token.Token{}, int64(position-1)), e
+		}
+
+		return nil, nil
+	}
 }
 
 func (ao *Array) Homogeneous() bool {
@@ -297,8 +920,12 @@ func (ao *Array) Empty() bool {
 func (ao *Array) Inspect() string {
 	var out bytes.Buffer
 
+	ao.mu.Lock()
+	snapshot := append([]Object(nil), ao.Elements...)
+	ao.mu.Unlock()
+
 	elements := []string{}
-	for _, e := range ao.Elements {
+	for _, e := range snapshot {
 		elements = append(elements, e.Json())
 	}
 
@@ -316,17 +943,34 @@ type HashPair struct {
 	Value Object
 }
 
+// Hash'in anahtarları ve sırası kilit altında değiştirilir; görevler
+// arasında paylaşılan bir haritaya aynı anda yazılabilir. Pairs doğrudan
+// okunmamalı, Get ya da OrderedPairs kullanılmalı.
+//
+// keys anahtarları eklenme sırasıyla tutar, index her anahtarın keys içindeki
+// yerini gösterir. Silinen anahtarın yeri keys'te kalır ve yarısı boşalınca
+// dizi sıkıştırılır, böylece silme sabit zamanlıdır.
 type Hash struct {
-	Token    token.Token
-	Pairs    map[HashKey]HashPair
-	Position int
+	Token token.Token
+	Pairs map[HashKey]HashPair
+	keys  []HashKey
+	index map[HashKey]int
+
+	mu sync.Mutex
 }
 
 func (h *Hash) Type() ObjectType { return HASH_OBJ }
-func (h *Hash) GetPair(key string) (HashPair, bool) {
-	record, ok := h.Pairs[HashKey{Type: "STRING", Value: key}]
 
-	return record, ok
+func (h *Hash) Get(hashed HashKey) (HashPair, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	pair, ok := h.Pairs[hashed]
+	return pair, ok
+}
+
+func (h *Hash) GetPair(key string) (HashPair, bool) {
+	return h.Get(HashKey{Type: "STRING", Value: key})
 }
 
 func (h *Hash) GetKeyType(k string) ObjectType {
@@ -339,14 +983,107 @@ func (h *Hash) GetKeyType(k string) ObjectType {
 	return pair.Value.Type()
 }
 
+
+
+func (h *Hash) Set(key Object, value Object) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.Pairs == nil {
+		h.Pairs = make(map[HashKey]HashPair)
+		h.index = make(map[HashKey]int)
+	}
+
+	hashed := key.(Hashable).HashKey()
+
+	if _, ok := h.Pairs[hashed]; !ok {
+		h.index[hashed] = len(h.keys)
+		h.keys = append(h.keys, hashed)
+	}
+
+	h.Pairs[hashed] = HashPair{Key: key, Value: value}
+}
+
+// Delete silinen çifti döner, anahtar yoksa false döner
+func (h *Hash) Delete(hashed HashKey) (HashPair, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	pair, ok := h.Pairs[hashed]
+	if !ok {
+		return pair, false
+	}
+
+	delete(h.Pairs, hashed)
+	delete(h.index, hashed)
+
+	if len(h.Pairs)*2 < len(h.keys) {
+		h.compact()
+	}
+
+	return pair, true
+}
+
+// live keys'teki i. yerin silinmemiş bir anahtar olduğunu söyler
+func (h *Hash) live(i int) bool {
+	position, ok := h.index[h.keys[i]]
+	return ok && position == i
+}
+
+func (h *Hash) compact() {
+	keys := make([]HashKey, 0, len(h.Pairs))
+
+	for i, k := range h.keys {
+		if h.live(i) {
+			h.index[k] = len(keys)
+			keys = append(keys, k)
+		}
+	}
+
+	h.keys = keys
+}
+
+func (h *Hash) OrderedPairs() []HashPair {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	pairs := make([]HashPair, 0, len(h.Pairs))
+
+	for i, k := range h.keys {
+		if h.live(i) {
+			pairs = append(pairs, h.Pairs[k])
+		}
+	}
+
+	return pairs
+}
+
 func (h *Hash) Inspect() string {
+	return h.format(false)
+}
+
+// JSON nesne anahtarları metin olmak zorunda, sayı gibi anahtarlar metne
+// çevrilir. Metne çevrildiğinde çakışan anahtarlar (1 ve "1") jsonla(...)
+// tarafından reddedilir.
+func (h *Hash) Json() string {
+	return h.format(true)
+}
+
+func (h *Hash) format(json bool) string {
 	var out bytes.Buffer
 
 	pairs := []string{}
-	for _, pair := range h.Pairs {
-		pairs = append(pairs, fmt.Sprintf(`%s: %s`, pair.Key.Json(), pair.Value.Json()))
+	for _, pair := range h.OrderedPairs() {
+		key, value := pair.Key.Json(), pair.Value.Json()
+
+		if json {
+			key = jsonString(pair.Key.Inspect())
+		} else if nested, ok := pair.Value.(*Hash); ok {
+			value = nested.Inspect()
+		}
+
+		pairs = append(pairs, fmt.Sprintf(`%s: %s`, key, value))
 	}
-	sort.Strings(pairs)
 
 	out.WriteString("{")
 	out.WriteString(strings.Join(pairs, ", "))
@@ -354,30 +1091,124 @@ func (h *Hash) Inspect() string {
 
 	return out.String()
 }
-func (h *Hash) Json() string { return h.Inspect() }
 
-func (h *Hash) Next() (Object, Object) {
-	curPosition := 0
-	pairs := make(map[string]HashPair)
-	var keys []string
-	for _, v := range h.Pairs {
-		pairs[v.Key.Inspect()] = v
-		keys = append(keys, v.Key.Inspect())
+// Iterator döngü başladığındaki anahtarları dolaşır; döngüde silinen
+// anahtarlar atlanır
+func (h *Hash) Iterator() func() (Object, Object) {
+	h.mu.Lock()
+	keys := make([]HashKey, 0, len(h.Pairs))
+	for i, k := range h.keys {
+		if h.live(i) {
+			keys = append(keys, k)
+		}
+	}
+	h.mu.Unlock()
+
+	return func() (Object, Object) {
+		for len(keys) > 0 {
+			pair, ok := h.Get(keys[0])
+			keys = keys[1:]
+
+			if ok {
+				return pair.Key, pair.Value
+			}
+		}
+
+		return nil, nil
+	}
+}
+
+// Set elemanları HashKey ile tutar, böylece üyelik sorgusu sabit zamanlıdır.
+// Elemanlar eklenme sırasıyla gezilir ve yazdırılır.
+type Set struct {
+	Token    token.Token
+	Elements map[HashKey]Object
+	keys     []HashKey
+}
+
+func NewSet(tok token.Token) *Set {
+	return &Set{Token: tok, Elements: make(map[HashKey]Object)}
+}
+
+func (s *Set) Type() ObjectType { return SET_OBJ }
+
+// Add eleman yeni eklendiyse true döner, eleman Hashable olmalı
+func (s *Set) Add(o Object) bool {
+	hashed := o.(Hashable).HashKey()
+
+	if _, ok := s.Elements[hashed]; ok {
+		return false
+	}
+
+	s.Elements[hashed] = o
+	s.keys = append(s.keys, hashed)
+	return true
+}
+
+func (s *Set) Has(o Object) bool {
+	key, ok := o.(Hashable)
+	if !ok {
+		return false
+	}
+
+	_, found := s.Elements[key.HashKey()]
+	return found
+}
+
+func (s *Set) Remove(o Object) bool {
+	if !s.Has(o) {
+		return false
 	}
 
-	sort.Strings(keys)
+	hashed := o.(Hashable).HashKey()
+	delete(s.Elements, hashed)
 
-	for _, k := range keys {
-		if h.Position == curPosition {
-			h.Position += 1
-			return pairs[k].Key, pairs[k].Value
+	for i, k := range s.keys {
+		if k == hashed {
+			s.keys = append(s.keys[:i], s.keys[i+1:]...)
+			break
 		}
+	}
+
+	return true
+}
 
-		curPosition += 1
+func (s *Set) Ordered() []Object {
+	elements := make([]Object, 0, len(s.keys))
+
+	for _, k := range s.keys {
+		elements = append(elements, s.Elements[k])
 	}
 
-	return nil, nil
+	return elements
+}
+
+func (s *Set) Inspect() string {
+	return "küme(" + (&Array{Elements: s.Ordered()}).Inspect() + ")"
+}
+
+// JSON'da küme bir dizi olarak yazılır
+func (s *Set) Json() string {
+	return (&Array{Elements: s.Ordered()}).Json()
 }
-func (h *Hash) Reset() {
-	h.Position = 0
+
+// Iterator döngü başladığındaki elemanları dolaşır; döngüde çıkarılan
+// elemanlar atlanır
+func (s *Set) Iterator() func() (Object, Object) {
+	keys := append([]HashKey(nil), s.keys...)
+	position := 0
+
+	return func() (Object, Object) {
+		for len(keys) > 0 {
+			element, ok := s.Elements[keys[0]]
+			keys = keys[1:]
+
+			if ok {
+				position++
+				return NewInteger(token.Token{}, int64(position-1)), element
+			}
+		}
+
+		return nil, nil
+	}
 }
@@ -2,8 +2,11 @@ package parser
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/ankalang/anka/ast"
 	"github.com/ankalang/anka/lexer"
@@ -70,9 +73,17 @@ type (
 	infixParseFn  func(ast.Expression) ast.Expression
 )
 
+// Error ayrıştırıcının bulduğu bir hatadır; Token hatanın bulunduğu yerdir
+type Error struct {
+	Message string
+	Token   token.Token
+	// Errors() çıktısındaki, renkli ve hatalı satırı gösteren ileti
+	text string
+}
+
 type Parser struct {
 	l      *lexer.Lexer
-	errors []string
+	errors []Error
 
 	curToken  token.Token
 	peekToken token.Token
@@ -90,12 +101,14 @@ type Parser struct {
 func New(l *lexer.Lexer) *Parser {
 	p := &Parser{
 		l:      l,
-		errors: []string{},
+		errors: []Error{},
 	}
 
 	p.prefixParseFns = make(map[token.TokenType]prefixParseFn)
 	p.registerPrefix(token.IDENT, p.parseIdentifier)
 	p.registerPrefix(token.NUMBER, p.ParseNumberLiteral)
+	p.registerPrefix(token.INT, p.ParseNumberLiteral)
+	p.registerPrefix(token.DECIMAL, p.ParseNumberLiteral)
 	p.registerPrefix(token.STRING, p.ParseStringLiteral)
 	p.registerPrefix(token.NULL, p.ParseNullLiteral)
 	p.registerPrefix(token.BANG, p.parsePrefixExpression)
@@ -106,6 +119,7 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerPrefix(token.FALSE, p.ParseBoolean)
 	p.registerPrefix(token.LPAREN, p.parseGroupedExpression)
 	p.registerPrefix(token.IF, p.parseIfExpression)
+	p.registerPrefix(token.MATCH, p.parseMatchExpression)
 	p.registerPrefix(token.WHILE, p.parseWhileExpression)
 	p.registerPrefix(token.FOR, p.parseForExpression)
 	p.registerPrefix(token.FUNCTION, p.parseFunctionLiteral)
@@ -117,6 +131,7 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerPrefix(token.CURRENT_ARGS, p.parseCurrentArgsLiteral)
 	p.registerPrefix(token.AT, p.parseDecorator)
 	p.registerPrefix(token.DEFER, p.parseDefer)
+	p.registerPrefix(token.TRY, p.parseTryExpression)
 
 	p.infixParseFns = make(map[token.TokenType]infixParseFn)
 	p.registerInfix(token.PLUS, p.parseInfixExpression)
@@ -189,20 +204,35 @@ func (p *Parser) expectPeek(typ token.TokenType) bool {
 }
 
 func (p *Parser) Errors() []string {
+	errors := make([]string, len(p.errors))
+	for i, err := range p.errors {
+		errors[i] = err.text
+	}
+
+	return errors
+}
+
+// ErrorList hataları konumlarıyla döner
+func (p *Parser) ErrorList() []Error {
 	return p.errors
 }
 
 func (p *Parser) reportError(err string, tok token.Token) {
-	
+	p.report(err, err, tok)
+}
+
+// report hatayı kaydeder; colored Errors() çıktısında message yerine yazılır
+func (p *Parser) report(message string, colored string, tok token.Token) {
 	lineNum, column, errorLine := p.l.ErrorLine(tok.Position)
-	msg := fmt.Sprintf("%s\n\033[97m\n%d:%d> %s ", err, lineNum, column, errorLine)
-	
-	p.errors = append(p.errors, msg)
+	msg := fmt.Sprintf("%s\n\033[97m\n%d:%d> %s ", colored, lineNum, column, errorLine)
+
+	p.errors = append(p.errors, Error{Message: message, Token: tok, text: msg})
 }
 
 func (p *Parser) peekError(tok token.Token) {
-	msg := fmt.Sprintf("\033[32m%s \033[97mbeklenilirken, \033[33m%s \033[97mbulundu", tok.Type, p.peekToken.Type)
-	p.reportError(msg, tok)
+	msg := fmt.Sprintf("%s beklenilirken, %s bulundu", tok.Type, p.peekToken.Type)
+	colored := fmt.Sprintf("\033[32m%s \033[97mbeklenilirken, \033[33m%s \033[97mbulundu", tok.Type, p.peekToken.Type)
+	p.report(msg, colored, tok)
 }
 
 func (p *Parser) noPrefixParseFnError(tok token.Token) {
@@ -230,6 +260,22 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseReturnStatement()
 	}
 
+	
+	
+	if p.curTokenIs(token.IDENT) && p.curToken.Literal == "tip" && p.peekTokenIs(token.IDENT) {
+		return p.parseTypeStatement()
+	}
+
+	if p.curTokenIs(token.VAR) || p.curTokenIs(token.CONST) {
+		return p.parseDeclaration()
+	}
+
+	if p.curTokenIs(token.LBRACKET) || p.curTokenIs(token.LBRACE) {
+		if statement := p.parseDestructuringAssignment(); statement != nil {
+			return statement
+		}
+	}
+
 	statement := p.parseAssignStatement()
 	if statement != nil {
 		return statement
@@ -238,6 +284,46 @@ func (p *Parser) parseStatement() ast.Statement {
 	return p.parseExpressionStatement()
 }
 
+// değişken x = 1, sabit PI = 3.14, değişken a, b = [1, 2] ya da değeri null olan değişken x
+func (p *Parser) parseDeclaration() ast.Statement {
+	declaration := p.curToken
+
+	if p.peekTokenIs(token.LBRACKET) || p.peekTokenIs(token.LBRACE) {
+		p.nextToken()
+
+		stmt := p.parsePatternAssignment()
+		if stmt == nil {
+			return nil
+		}
+
+		stmt.Declaration = &declaration
+		return stmt
+	}
+
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+
+	if declaration.Type == token.VAR && !p.peekTokenIs(token.ASSIGN) && !p.peekTokenIs(token.COMMA) {
+		stmt := &ast.AssignStatement{Token: declaration, Declaration: &declaration, Name: &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}}
+
+		if p.peekTokenIs(token.SEMICOLON) {
+			p.nextToken()
+		}
+
+		return stmt
+	}
+
+	stmt, ok := p.parseAssignStatement().(*ast.AssignStatement)
+	if !ok {
+		p.reportError(fmt.Sprintf("%s bildiriminden sonra '=' ve bir değer bekleniyordu", declaration.Literal), p.peekToken)
+		return nil
+	}
+
+	stmt.Declaration = &declaration
+	return stmt
+}
+
 
 
 
@@ -348,6 +434,95 @@ func (p *Parser) parseAssignStatement() ast.Statement {
 }
 
 
+// parseDestructuringAssignment [ ya da { ile başlayan satırın bir kalıp ataması
+// olup olmadığına bakar; değilse ayrıştırıcıyı geri sarar ve nil döner, satır
+// dizi ya da harita ifadesi olarak okunur
+func (p *Parser) parseDestructuringAssignment() ast.Statement {
+	lexerPosition := p.l.CurrentPosition()
+	errors := len(p.errors)
+
+	if stmt := p.parsePatternAssignment(); stmt != nil && len(p.errors) == errors {
+		return stmt
+	}
+
+	p.Rewind(lexerPosition)
+	p.errors = p.errors[:errors]
+	return nil
+}
+
+// [ilk, ...kalan] = liste ya da {ad, yaş: y} = kişi
+func (p *Parser) parsePatternAssignment() *ast.AssignStatement {
+	pattern := p.parseSinglePattern()
+	if pattern == nil {
+		return nil
+	}
+
+	if !p.expectPeek(token.ASSIGN) {
+		return nil
+	}
+
+	stmt := &ast.AssignStatement{Token: p.curToken, Pattern: pattern}
+	p.nextToken()
+
+	stmt.Value = p.parseExpression(LOWEST)
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseTypeStatement() ast.Statement {
+	stmt := &ast.TypeStatement{Token: p.curToken}
+	p.nextToken()
+	stmt.Name = p.curToken.Literal
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	p.nextToken()
+
+	for !p.curTokenIs(token.RBRACE) {
+		switch {
+		case p.curTokenIs(token.EOF):
+			p.reportError(fmt.Sprintf("%s tipinin gövdesi kapatılmamış", stmt.Name), stmt.Token)
+			return nil
+		case p.curTokenIs(token.SEMICOLON) || p.curTokenIs(token.COMMA):
+		case p.curTokenIs(token.FUNCTION):
+			method, ok := p.parseFunctionLiteral().(*ast.FunctionLiteral)
+			if !ok || method == nil {
+				return nil
+			}
+
+			if method.Name == "" {
+				p.reportError(fmt.Sprintf("%s tipinin metotlarının bir adı olmalı", stmt.Name), method.Token)
+				return nil
+			}
+
+			stmt.Methods = append(stmt.Methods, method)
+		case p.curTokenIs(token.IDENT):
+			field := &ast.TypeField{Name: p.curToken.Literal}
+
+			if p.peekTokenIs(token.ASSIGN) {
+				p.nextToken()
+				p.nextToken()
+				field.Default = p.parseExpression(LOWEST)
+			}
+
+			stmt.Fields = append(stmt.Fields, field)
+		default:
+			p.reportError(fmt.Sprintf("%s tipinin gövdesinde beklenmeyen %q", stmt.Name, p.curToken.Literal), p.curToken)
+			return nil
+		}
+
+		p.nextToken()
+	}
+
+	return stmt
+}
+
 func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	stmt := &ast.ReturnStatement{Token: p.curToken}
 	returnToken := p.curToken
@@ -399,6 +574,12 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 			return leftExp
 		}
 
+		// satır başındaki [ yeni bir ifadedir ([ilk, ...kalan] = liste),
+		// önceki satırın dizinlemesi değildir
+		if p.peekTokenIs(token.LBRACKET) && p.l.StartsLine(p.peekToken.Position) {
+			return leftExp
+		}
+
 		p.nextToken()
 
 		leftExp = infix(leftExp)
@@ -435,11 +616,41 @@ func (p *Parser) ParseNumberLiteral() ast.Expression {
 	var ok bool
 	number := p.curToken.Literal
 
+	if p.curTokenIs(token.DECIMAL) {
+		r, ok := new(big.Rat).SetString(number)
+		if !ok {
+			msg := fmt.Sprintf("could not parse %q as decimal", number)
+			p.reportError(msg, p.curToken)
+			return nil
+		}
+
+		lit.Value, _ = r.Float64()
+		return lit
+	}
+
 	
 	if abbr, ok = token.NumberAbbreviations[strings.ToLower(string(number[len(number)-1]))]; ok {
 		number = p.curToken.Literal[:len(p.curToken.Literal)-1]
 	}
 
+	if p.curTokenIs(token.INT) {
+		integer, ok := new(big.Int).SetString(number, 10)
+		if !ok {
+			msg := fmt.Sprintf("could not parse %q as number", number)
+			p.reportError(msg, p.curToken)
+			return nil
+		}
+
+		if abbr != 0 {
+			integer.Mul(integer, big.NewInt(int64(abbr)))
+		}
+
+		lit.Integer = integer
+		lit.Value, _ = new(big.Float).SetInt(integer).Float64()
+
+		return lit
+	}
+
 	value, err := strconv.ParseFloat(number, 64)
 	if err != nil {
 		msg := fmt.Sprintf("could not parse %q as number", number)
@@ -534,9 +745,15 @@ func (p *Parser) parseDottedExpression(object ast.Expression) ast.Expression {
 	
 	if p.peekTokenIs(token.LPAREN) {
 		exp := &ast.MethodExpression{Token: t, Object: object}
-		exp.Method = p.parseExpression(precedence)
+
+		// anahtar kelimeler de metot adı olabilir, örn. görev.bekle()
+		if token.LookupIdent(p.curToken.Literal) != token.IDENT {
+			exp.Method = p.parseIdentifier()
+		} else {
+			exp.Method = p.parseExpression(precedence)
+		}
 		p.nextToken()
-		exp.Arguments = p.parseExpressionList(token.RPAREN)
+		exp.Arguments, exp.Keywords = p.parseCallArguments()
 		return exp
 	} else {
 		
@@ -574,7 +791,7 @@ func (p *Parser) parseMethodExpression(object ast.Expression) ast.Expression {
 	p.nextToken()
 	exp.Method = p.parseExpression(precedence)
 	p.nextToken()
-	exp.Arguments = p.parseExpressionList(token.RPAREN)
+	exp.Arguments, exp.Keywords = p.parseCallArguments()
 	return exp
 }
 
@@ -655,6 +872,263 @@ func (p *Parser) parseIfExpression() ast.Expression {
 
 
 
+func (p *Parser) parseMatchExpression() ast.Expression {
+	expression := &ast.MatchExpression{Token: p.curToken}
+
+	p.nextToken()
+	expression.Subject = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	p.nextToken()
+
+	for !p.curTokenIs(token.RBRACE) {
+		if p.curTokenIs(token.EOF) {
+			p.reportError("eşle ifadesi kapatılmamış, '}' bekleniyordu", expression.Token)
+			return nil
+		}
+
+		arm := &ast.MatchArm{Pattern: p.parsePattern()}
+		if arm.Pattern == nil {
+			return nil
+		}
+
+		if p.peekTokenIs(token.IF) {
+			p.nextToken()
+			p.nextToken()
+			arm.Guard = p.parseExpression(LOWEST)
+		}
+
+		if !p.expectPeek(token.ARROW) {
+			return nil
+		}
+
+		p.nextToken()
+
+		if p.curTokenIs(token.LBRACE) {
+			arm.Body = p.parseBlockStatement()
+		} else {
+			// tek ifadeli kollar, ifadenin değerini dönen bir blok olarak saklanır
+			tok := p.curToken
+			arm.Body = &ast.BlockStatement{Token: tok, Statements: []ast.Statement{
+				&ast.ExpressionStatement{Token: tok, Expression: p.parseExpression(LOWEST)},
+			}}
+		}
+
+		expression.Arms = append(expression.Arms, arm)
+		p.nextToken()
+
+		for p.curTokenIs(token.COMMA) || p.curTokenIs(token.SEMICOLON) {
+			p.nextToken()
+		}
+	}
+
+	return expression
+}
+
+// parsePattern eşle kollarındaki kalıpları okur, | ile ayrılmış seçenekleri birleştirir
+func (p *Parser) parsePattern() ast.Pattern {
+	tok := p.curToken
+
+	pattern := p.parseSinglePattern()
+	if pattern == nil || !p.peekTokenIs(token.PIPE) {
+		return pattern
+	}
+
+	alternative := &ast.AlternativePattern{Token: tok, Options: []ast.Pattern{pattern}}
+	for p.peekTokenIs(token.PIPE) {
+		p.nextToken()
+		p.nextToken()
+
+		option := p.parseSinglePattern()
+		if option == nil {
+			return nil
+		}
+
+		alternative.Options = append(alternative.Options, option)
+	}
+
+	return alternative
+}
+
+func (p *Parser) parseSinglePattern() ast.Pattern {
+	tok := p.curToken
+
+	switch tok.Type {
+	case token.IDENT:
+		if tok.Literal == "_" {
+			return &ast.WildcardPattern{Token: tok}
+		}
+
+		// büyük harfle başlayan adlar tip, diğerleri değerin bağlanacağı addır
+		if first, _ := utf8.DecodeRuneInString(tok.Literal); unicode.IsUpper(first) {
+			pattern := &ast.TypePattern{Token: tok, TypeName: tok.Literal}
+
+			switch {
+			case p.peekTokenIs(token.IDENT):
+				p.nextToken()
+				pattern.Binding = p.curToken.Literal
+			case p.peekTokenIs(token.LBRACE), p.peekTokenIs(token.LBRACKET):
+				p.nextToken()
+
+				pattern.Inner = p.parseSinglePattern()
+				if pattern.Inner == nil {
+					return nil
+				}
+			}
+
+			return pattern
+		}
+
+		return &ast.BindingPattern{Token: tok, Name: tok.Literal}
+	case token.LBRACKET:
+		return p.parseArrayPattern()
+	case token.LBRACE:
+		return p.parseHashPattern()
+	case token.NUMBER, token.INT, token.DECIMAL, token.STRING, token.MINUS, token.TRUE, token.FALSE, token.NULL:
+		value := p.parseExpression(PREFIX)
+		if value == nil {
+			return nil
+		}
+
+		if !p.peekTokenIs(token.RANGE) {
+			return &ast.LiteralPattern{Token: tok, Value: value}
+		}
+
+		p.nextToken()
+		p.nextToken()
+
+		to := p.parseExpression(PREFIX)
+		if to == nil {
+			return nil
+		}
+
+		return &ast.RangePattern{Token: tok, From: value, To: to}
+	}
+
+	p.reportError(fmt.Sprintf("eşle kalıbı olarak beklenmeyen %q", tok.Literal), tok)
+	return nil
+}
+
+// [a, b], [ilk, ...kalan] ya da [ilk, ...]
+func (p *Parser) parseArrayPattern() ast.Pattern {
+	pattern := &ast.ArrayPattern{Token: p.curToken}
+
+	for !p.peekTokenIs(token.RBRACKET) {
+		p.nextToken()
+
+		if p.curTokenIs(token.CURRENT_ARGS) {
+			pattern.HasRest = true
+
+			if p.peekTokenIs(token.IDENT) {
+				p.nextToken()
+				pattern.Rest = p.curToken.Literal
+			}
+
+			break
+		}
+
+		element := p.parsePatternDefault(p.parsePattern())
+		if element == nil {
+			return nil
+		}
+
+		pattern.Elements = append(pattern.Elements, element)
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+
+		p.nextToken()
+	}
+
+	if !p.expectPeek(token.RBRACKET) {
+		return nil
+	}
+
+	return pattern
+}
+
+// {"ad": ad, "yaş": 18..99}, {ad, yaş: y, ...geri} ya da {port = 80}
+func (p *Parser) parseHashPattern() ast.Pattern {
+	pattern := &ast.HashPattern{Token: p.curToken}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+
+		switch {
+		case p.curTokenIs(token.CURRENT_ARGS):
+			pattern.HasRest = true
+
+			if p.peekTokenIs(token.IDENT) {
+				p.nextToken()
+				pattern.Rest = p.curToken.Literal
+			}
+		case p.curTokenIs(token.IDENT) && !p.peekTokenIs(token.COLON):
+			key := p.curToken.Literal
+
+			value := p.parsePatternDefault(&ast.BindingPattern{Token: p.curToken, Name: key})
+			if value == nil {
+				return nil
+			}
+
+			pattern.Keys = append(pattern.Keys, key)
+			pattern.Values = append(pattern.Values, value)
+		case p.curTokenIs(token.IDENT), p.curTokenIs(token.STRING):
+			key := p.curToken.Literal
+
+			if !p.expectPeek(token.COLON) {
+				return nil
+			}
+
+			p.nextToken()
+
+			value := p.parsePatternDefault(p.parsePattern())
+			if value == nil {
+				return nil
+			}
+
+			pattern.Keys = append(pattern.Keys, key)
+			pattern.Values = append(pattern.Values, value)
+		default:
+			p.reportError(fmt.Sprintf("harita kalıbında anahtar bekleniyordu, %q bulundu", p.curToken.Literal), p.curToken)
+			return nil
+		}
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+
+		p.nextToken()
+	}
+
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+
+	return pattern
+}
+
+// parsePatternDefault kalıbın ardından gelen "= değer" varsayılanını okur
+func (p *Parser) parsePatternDefault(pattern ast.Pattern) ast.Pattern {
+	if pattern == nil || !p.peekTokenIs(token.ASSIGN) {
+		return pattern
+	}
+
+	p.nextToken()
+	tok := p.curToken
+	p.nextToken()
+
+	value := p.parseExpression(LOWEST)
+	if value == nil {
+		return nil
+	}
+
+	return &ast.DefaultPattern{Token: tok, Pattern: pattern, Default: value}
+}
+
 func (p *Parser) parseWhileExpression() ast.Expression {
 	expression := &ast.WhileExpression{Token: p.curToken}
 
@@ -771,6 +1245,50 @@ func (p *Parser) parseForInExpression(initialExpression *ast.ForExpression) ast.
 }
 
 
+
+func (p *Parser) parseTryExpression() ast.Expression {
+	expression := &ast.TryExpression{Token: p.curToken}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	expression.Block = p.parseBlockStatement()
+
+	if p.peekTokenIs(token.CATCH) {
+		p.nextToken()
+
+		if p.peekTokenIs(token.IDENT) {
+			p.nextToken()
+			expression.Identifier = p.curToken.Literal
+		}
+
+		if !p.expectPeek(token.LBRACE) {
+			return nil
+		}
+
+		expression.Catch = p.parseBlockStatement()
+	}
+
+	if p.peekTokenIs(token.FINALLY) {
+		p.nextToken()
+
+		if !p.expectPeek(token.LBRACE) {
+			return nil
+		}
+
+		expression.Finally = p.parseBlockStatement()
+	}
+
+	if expression.Catch == nil && expression.Finally == nil {
+		p.reportError("\"dene\" bloğundan sonra \"yakala\" ya da \"sonunda\" bekleniyordu", expression.Token)
+		return nil
+	}
+
+	return expression
+}
+
+
 func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 	block := &ast.BlockStatement{Token: p.curToken}
 	block.Statements = []ast.Statement{}
@@ -810,6 +1328,7 @@ func (p *Parser) parseFunctionLiteral() ast.Expression {
 	}
 
 	lit.Body = p.parseBlockStatement()
+	ast.MarkTailCalls(lit.Body)
 
 	return lit
 }
@@ -887,9 +1406,13 @@ func (p *Parser) parseFunctionParameters() []*ast.Parameter {
 		p.nextToken()
 		p.nextToken()
 
+		if last := parameters[len(parameters)-1]; last.Rest {
+			p.reportError(fmt.Sprintf("...%s kalan parametresi son parametre olmalı.", last.Value), last.Token)
+		}
+
 		param, optional := p.parseFunctionParameter()
 
-		if foundOptionalParameter && !optional {
+		if foundOptionalParameter && !optional && !param.Rest {
 			p.reportError("zorunlu parametrenin ardından isteğe bağlı parametre bulundu.", p.curToken)
 		}
 
@@ -911,6 +1434,16 @@ func (p *Parser) parseFunctionParameters() []*ast.Parameter {
 
 
 func (p *Parser) parseFunctionParameter() (param *ast.Parameter, optional bool) {
+	if p.curTokenIs(token.LBRACKET) || p.curTokenIs(token.LBRACE) {
+		return p.parsePatternParameter()
+	}
+
+	// f(a, ...diğerleri)
+	if p.curTokenIs(token.CURRENT_ARGS) && p.peekTokenIs(token.IDENT) {
+		p.nextToken()
+		return &ast.Parameter{Identifier: &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}, Rest: true}, false
+	}
+
 	
 	ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
 
@@ -947,12 +1480,84 @@ func (p *Parser) parseFunctionParameter() (param *ast.Parameter, optional bool)
 }
 
 
+// f([x, y]) ya da f({port = 80} = {}) gibi argümanı kalıpla açılan parametreler
+func (p *Parser) parsePatternParameter() (*ast.Parameter, bool) {
+	tok := p.curToken
+
+	pattern := p.parseSinglePattern()
+	if pattern == nil {
+		return &ast.Parameter{Identifier: &ast.Identifier{Token: tok, Value: tok.Literal}}, false
+	}
+
+	param := &ast.Parameter{Identifier: &ast.Identifier{Token: tok, Value: pattern.String()}, Pattern: pattern}
+
+	if !p.peekTokenIs(token.ASSIGN) {
+		return param, false
+	}
+
+	p.nextToken()
+	p.nextToken()
+
+	param.Default = p.parseExpression(LOWEST)
+	return param, true
+}
+
 func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
 	exp := &ast.CallExpression{Token: p.curToken, Function: function}
-	exp.Arguments = p.parseExpressionList(token.RPAREN)
+	exp.Arguments, exp.Keywords = p.parseCallArguments()
 	return exp
 }
 
+// parseCallArguments sıralı argümanları ve ardından gelen ad: değer
+// biçimindeki isimli argümanları okur
+func (p *Parser) parseCallArguments() ([]ast.Expression, []*ast.KeywordArgument) {
+	args := []ast.Expression{}
+	var keywords []*ast.KeywordArgument
+
+	if p.peekTokenIs(token.RPAREN) {
+		p.nextToken()
+		return args, keywords
+	}
+
+	for {
+		p.nextToken()
+
+		if p.curTokenIs(token.IDENT) && p.peekTokenIs(token.COLON) {
+			keyword := &ast.KeywordArgument{Token: p.curToken, Name: p.curToken.Literal}
+
+			for _, k := range keywords {
+				if k.Name == keyword.Name {
+					p.reportError(fmt.Sprintf("'%s' isimli argümanı birden fazla kez verildi.", keyword.Name), keyword.Token)
+				}
+			}
+
+			p.nextToken()
+			p.nextToken()
+
+			keyword.Value = p.parseExpression(LOWEST)
+			keywords = append(keywords, keyword)
+		} else {
+			if len(keywords) > 0 {
+				p.reportError("isimli argümanlardan sonra sıralı argüman gelemez.", p.curToken)
+			}
+
+			args = append(args, p.parseExpression(LOWEST))
+		}
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+
+		p.nextToken()
+	}
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil, nil
+	}
+
+	return args, keywords
+}
+
 func (p *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
 	list := []ast.Expression{}
 
@@ -991,7 +1596,7 @@ func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 	exp := &ast.IndexExpression{Token: p.curToken, Left: left}
 
 	if p.peekTokenIs(token.COLON) {
-		exp.Index = &ast.NumberLiteral{Value: 0, Token: token.Token{Type: token.NUMBER, Position: 0, Literal: "0"}}
+		exp.Index = &ast.NumberLiteral{Value: 0, Integer: big.NewInt(0), Token: token.Token{Type: token.INT, Position: 0, Literal: "0"}}
 		exp.IsRange = true
 	} else {
 		p.nextToken()
@@ -1036,6 +1641,7 @@ func (p *Parser) ParseHashLiteral() ast.Expression {
 		p.nextToken()
 		value := p.parseExpression(LOWEST)
 
+		hash.Keys = append(hash.Keys, key)
 		hash.Pairs[key] = value
 
 		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
@@ -0,0 +1,95 @@
+package repl
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ankalang/anka/ast"
+	"github.com/ankalang/anka/compiler"
+	"github.com/ankalang/anka/evaluator"
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/vm"
+)
+
+func hasFlag(args []string, flag string) bool {
+	for _, arg := range args[2:] {
+		if arg == flag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RunCompiled programı bayt koduna derleyip sanal makinede çalıştırır
+func (r *Repl) RunCompiled(code string) {
+	program, _ := parse(code, r.file, false)
+	r.report(r.runCompiled(program), false)
+}
+
+// runCompiled derlenemeyen programları nedenini bildirerek ağaç
+// yorumlayıcısına bırakır
+func (r *Repl) runCompiled(program *ast.Program) object.Object {
+	bytecode, err := compiler.New(r.env.GetKeys()).Compile(program)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sanal makine: %s, program ağaç yorumlayıcısıyla çalıştırılıyor\n", err)
+		r.fallback = err
+		return r.interpreter.Run(program, r.env)
+	}
+
+	r.interpreter.Attach(r.env)
+	return vm.New(bytecode, r.env).Run()
+}
+
+// Compare programı ağaç yorumlayıcısında ve sanal makinede ayrı ortamlarda
+// çalıştırıp çıktılarını karşılaştırır; çıktılar farklıysa çıkış kodu 1'dir
+func Compare(path string, code string, version string) {
+	tree, compiled, fallback := compareEngines(path, code, version)
+
+	if fallback != nil {
+		fmt.Println("Program derlenemediği için sanal makinede çalıştırılamadı.")
+		os.Exit(1)
+	}
+
+	if tree == compiled {
+		fmt.Println("Ağaç yorumlayıcısı ve sanal makine aynı çıktıyı verdi.")
+		return
+	}
+
+	fmt.Printf("--- ağaç yorumlayıcısı ---\n%s--- sanal makine ---\n%s", tree, compiled)
+	os.Exit(1)
+}
+
+// compareEngines programın ağaç yorumlayıcısındaki ve sanal makinedeki
+// çıktılarını döner; program derlenemediyse derleme hatasını da döner
+func compareEngines(path string, code string, version string) (string, string, error) {
+	tree, _ := compareSession(path, version, func(r *Repl, program *ast.Program) object.Object {
+		return r.interpreter.Run(program, r.env)
+	}, code)
+
+	compiled, session := compareSession(path, version, func(r *Repl, program *ast.Program) object.Object {
+		return r.runCompiled(program)
+	}, code)
+
+	return tree, compiled, session.fallback
+}
+
+func compareSession(path string, version string, run func(*Repl, *ast.Program) object.Object, code string) (string, *Repl) {
+	var out bytes.Buffer
+
+	session := New(version)
+	session.env.Writer = &out
+	session.env.Set("ANK_INTERACTIVE", evaluator.FALSE)
+	session.env.Dir = filepath.Dir(path)
+
+	program, _ := parse(code, path, false)
+	evaluated := run(session, program)
+
+	if evaluated != nil && evaluated.Type() == object.ERROR_OBJ {
+		fmt.Fprintf(&out, "%s\n%s", evaluated.Inspect(), traceback(evaluated))
+	}
+
+	return out.String(), session
+}
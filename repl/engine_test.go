@@ -0,0 +1,64 @@
+package repl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testdata altındaki her program ağaç yorumlayıcısında ve sanal makinede
+// aynı çıktıyı vermeli
+func TestEnginesAgree(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.ank"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) == 0 {
+		t.Fatal("testdata altında program yok")
+	}
+
+	for _, file := range files {
+		code, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tree, compiled, fallback := compareEngines(file, string(code), "test")
+
+		if fallback != nil {
+			t.Errorf("%s: sanal makinede çalıştırılamadı: %s", file, fallback)
+		}
+
+		if tree == "" {
+			t.Errorf("%s: çıktı yok", file)
+		}
+
+		if tree != compiled {
+			t.Errorf("%s: çıktılar farklı\n--- ağaç yorumlayıcısı ---\n%s--- sanal makine ---\n%s", file, tree, compiled)
+		}
+	}
+}
+
+// derlenemeyip ağaç yorumlayıcısına bırakılan program karşılaştırmada
+// bildirilmeli
+func TestEngineFallbackReported(t *testing.T) {
+	var code strings.Builder
+	code.WriteString("d = [")
+	for i := 0; i < 70000; i++ {
+		fmt.Fprintf(&code, "%d, ", i)
+	}
+	code.WriteString("0]\neko(d.uzunluk())\n")
+
+	tree, compiled, fallback := compareEngines("büyük.ank", code.String(), "test")
+
+	if fallback == nil {
+		t.Fatal("derlenemeyen program bildirilmedi")
+	}
+
+	if tree != "70001\n" || compiled != tree {
+		t.Errorf("çıktılar: %q, %q", tree, compiled)
+	}
+}
@@ -13,7 +13,7 @@ import (
 
 const ANK_INIT_FILE = "~/.ankrc"
 
-func getAbsInitFile(interactive bool) {
+func (r *Repl) getAbsInitFile(interactive bool) {
 	
 	initFile := os.Getenv("ANK_INIT_FILE")
 	if len(initFile) == 0 {
@@ -32,7 +32,7 @@ func getAbsInitFile(interactive bool) {
 		
 		return
 	}
-	Run(string(code), interactive)
+	r.Run(string(code), interactive)
 }
 
 
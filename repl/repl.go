@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/ankalang/anka/ast"
 	"github.com/ankalang/anka/evaluator"
 	"github.com/ankalang/anka/lexer"
 	"github.com/ankalang/anka/object"
@@ -21,18 +22,27 @@ import (
 )
 
 
-var env *object.Environment
-
+// Repl tek bir ANK oturumunun ortamını ve yorumlayıcısını taşır
+type Repl struct {
+	env         *object.Environment
+	interpreter *evaluator.Interpreter
+	// çalıştırılan dosya; etkileşimli oturumda boştur
+	file string
+	// derlenemeyip ağaç yorumlayıcısıyla çalıştırılan son programın hatası
+	fallback error
+}
 
-func init() {
+func New(version string) *Repl {
 	d, _ := os.Getwd()
-	env = object.NewEnvironment(os.Stdout, d, "")
+	env := object.NewEnvironment(os.Stdout, d, version)
+
+	return &Repl{env: env, interpreter: evaluator.NewInterpreter(os.Stdin)}
 }
 
-func completer(d prompt.Document) []prompt.Suggest {
+func (r *Repl) completer(d prompt.Document) []prompt.Suggest {
 	s := []prompt.Suggest{}
 
-	for _, key := range env.GetKeys() {
+	for _, key := range r.env.GetKeys() {
 		s = append(s, prompt.Suggest{Text: key})
 	}
 
@@ -53,14 +63,14 @@ func changeLivePrefix() (string, bool) {
 	return livePrefix, LivePrefixState.IsEnable
 }
 
-func Start(in io.Reader, out io.Writer) {
+func (r *Repl) Start(in io.Reader, out io.Writer) {
 	if !terminal.IsTerminal(int(os.Stdout.Fd())) {
 		fmt.Println("ANKA interaktif hali başlatılamadı (terminal yok)")
 		os.Exit(1)
 	}
 
-	promptPrefix := util.GetEnvVar(env, "ANK_PROMPT_PREFIX", ANK_PROMPT_PREFIX)
-	livePrompt := util.GetEnvVar(env, "ANK_PROMPT_LIVE_PREFIX", "false")
+	promptPrefix := util.GetEnvVar(r.env, "ANK_PROMPT_PREFIX", ANK_PROMPT_PREFIX)
+	livePrompt := util.GetEnvVar(r.env, "ANK_PROMPT_LIVE_PREFIX", "false")
 	if livePrompt == "true" {
 		LivePrefixState.LivePrefix = promptPrefix
 		LivePrefixState.IsEnable = true
@@ -71,8 +81,8 @@ func Start(in io.Reader, out io.Writer) {
 	}
 
 	p := prompt.New(
-		executor,
-		completer,
+		r.executor,
+		r.completer,
 		prompt.OptionPrefix(promptPrefix),
 		prompt.OptionLivePrefix(changeLivePrefix),
 		prompt.OptionTitle("anka-repl"),
@@ -81,7 +91,7 @@ func Start(in io.Reader, out io.Writer) {
 	p.Run()
 }
 
-func executor(line string) {
+func (r *Repl) executor(line string) {
 	if line == "çık" {
 		fmt.Printf("%s\n", "Görüşürüz!")
 		os.Exit(0)
@@ -92,11 +102,20 @@ func executor(line string) {
 		return
 	}
 
-	Run(line, true)
+	r.Run(line, true)
+}
+
+func (r *Repl) Run(code string, interactive bool) {
+	program, ok := parse(code, r.file, interactive)
+	if !ok {
+		return
+	}
+
+	r.report(r.interpreter.Run(program, r.env), interactive)
 }
 
-func Run(code string, interactive bool) {
-	lex := lexer.New(code)
+func parse(code string, file string, interactive bool) (*ast.Program, bool) {
+	lex := lexer.NewFile(code, file)
 	p := parser.New(lex)
 
 	program := p.ParseProgram()
@@ -105,17 +124,20 @@ func Run(code string, interactive bool) {
 		if !interactive {
 			os.Exit(99)
 		}
-		return
+		return nil, false
 	}
 
-	evaluated := evaluator.BeginEval(program, env, lex)
+	return program, true
+}
 
+func (r *Repl) report(evaluated object.Object, interactive bool) {
 	if evaluated != nil {
 		isError := evaluated.Type() == object.ERROR_OBJ
 
 		if isError {
 			fmt.Printf("%s", evaluated.Inspect())
 			fmt.Println("")
+			fmt.Print(traceback(evaluated))
 
 			if !interactive {
 				os.Exit(99)
@@ -130,6 +152,15 @@ func Run(code string, interactive bool) {
 	}
 }
 
+// traceback hatanın çağrı yığınını döner
+func traceback(evaluated object.Object) string {
+	if err, ok := evaluated.(*object.Error); ok {
+		return err.Traceback()
+	}
+
+	return ""
+}
+
 func printParserErrors(errors []string) {
 	fmt.Printf("%s", " Ayrıştırıcı hatası:\n")
 	for _, msg := range errors {
@@ -138,6 +169,9 @@ func printParserErrors(errors []string) {
 }
 
 func BeginRepl(args []string, version string) {
+	session := New(version)
+	env := session.env
+
 	var interactive bool
 	if len(args) == 1 || strings.HasPrefix(args[1], "-") {
 		interactive = true
@@ -146,10 +180,9 @@ func BeginRepl(args []string, version string) {
 		interactive = false
 		env.Set("ANK_INTERACTIVE", evaluator.FALSE)
 		env.Dir = filepath.Dir(args[1])
+		session.file = args[1]
 	}
-	env.Version = version
-	env.Set("ANK_VERSION", &object.String{Value: version})
-	getAbsInitFile(interactive)
+	session.getAbsInitFile(interactive)
 
 	if interactive {
 		for k, v := range evaluator.Fns {
@@ -163,7 +196,7 @@ func BeginRepl(args []string, version string) {
 			}
 		}
 		fmt.Printf("İşin bittiğinde '\x1B[38;2;0;200;240mçık\x1B[38;2;255;255;255m' yaz, kaybolduğunda ise '\x1B[38;2;0;200;240myardım\x1B[38;2;255;255;255m'!\n")
-		Start(os.Stdin, os.Stdout)
+		session.Start(os.Stdin, os.Stdout)
 	} else {
 
 		code, err := ioutil.ReadFile(args[1])
@@ -172,7 +205,14 @@ func BeginRepl(args []string, version string) {
 			os.Exit(99)
 		}
 
-		Run(string(code), false)
+		switch {
+		case hasFlag(args, "--karşılaştır"):
+			Compare(args[1], string(code), version)
+		case hasFlag(args, "--vm"):
+			session.RunCompiled(string(code))
+		default:
+			session.Run(string(code), false)
+		}
 	}
 
 }
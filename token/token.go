@@ -8,7 +8,9 @@ const (
 
 	// Identifiers + literals
 	IDENT        = "Tanımlayıcı"  // add, foobar, x, y, ...
-	NUMBER       = "Sayı" // 1343456, 1.23456
+	NUMBER       = "Sayı" // 1.23456, 1e6
+	INT          = "Tamsayı" // 1343456, 1k
+	DECIMAL      = "Ondalık" // 12.30d
 	STRING       = "Yazı" // "foobar"
 	AT           = "@"      // @ At symbol
 	NULL         = "NULL"   // # null
@@ -65,7 +67,10 @@ const (
 	RBRACKET = "]"
 	DOT      = "."
 	QUESTION = "?"
+	ARROW    = "=>"
 	COMMAND  = "$()"
+	// yorumlar yalnızca KeepComments ile açılmış tarayıcılarda token olur
+	COMMENT  = "Yorum"
 
 	// Keywords
 	FUNCTION = "F"
@@ -81,12 +86,27 @@ const (
 	BREAK    = "Dur"
 	CONTINUE = "Devam"
 	DEFER    = "Bekleme"
+	TRY      = "Dene"
+	CATCH    = "Yakala"
+	FINALLY  = "Sonunda"
+	VAR      = "Değişken"
+	CONST    = "Sabit"
+	MATCH    = "Eşle"
 )
 
 type Token struct {
 	Type     TokenType
 	Position int // lexer position in file before token
 	Literal  string
+	Source   Source // input the token was read from, used to locate errors
+}
+
+// Source resolves a token position to its line, column and line text
+type Source interface {
+	ErrorLine(pos int) (int, int, string)
+	// File is the name of the file the input was read from, empty when the
+	// input didn't come from a file
+	File() string
 }
 
 var keywords = map[string]TokenType{
@@ -103,6 +123,12 @@ var keywords = map[string]TokenType{
 	"dur":    BREAK,
 	"devam": CONTINUE,
 	"bekle":    DEFER,
+	"dene":     TRY,
+	"yakala":   CATCH,
+	"sonunda":  FINALLY,
+	"değişken": VAR,
+	"sabit":    CONST,
+	"eşle":     MATCH,
 }
 
 // NumberAbbreviations is a list of abbreviations that can be used in numbers eg. 1k, 20B
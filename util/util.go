@@ -52,25 +52,31 @@ func GetEnvVar(env *object.Environment, varName, defaultVal string) string {
 	return value
 }
 
-func InterpolateStringVars(str string, env *object.Environment) string {
+var stringVarPattern = regexp.MustCompile("(\\\\)?\\$(\\{)?([a-zA-Z_0-9]{1,})(\\})?")
 
-	re := regexp.MustCompile("(\\\\)?\\$(\\{)?([a-zA-Z_0-9]{1,})(\\})?")
-	str = re.ReplaceAllStringFunc(str, func(m string) string {
+// stringVar yazıdaki bir $ad eşleşmesini çözer: değişken değilse eşleşmenin
+// yerine konacak metni döner
+func stringVar(m string) (name string, text string, isVar bool) {
+	if string(m[0]) == "\\" {
+		return "", m[1:], false
+	}
 
-		if string(m[0]) == "\\" {
-			return m[1:]
+	if m[1] == '{' {
+		if m[len(m)-1] != '}' {
+			return "", m, false
 		}
 
-		varName := ""
-		if m[1] == '{' {
+		return m[2 : len(m)-1], "", true
+	}
 
-			if m[len(m)-1] != '}' {
-				return m
-			}
+	return m[1:], "", true
+}
 
-			varName = m[2 : len(m)-1]
-		} else {
-			varName = m[1:]
+func InterpolateStringVars(str string, env *object.Environment) string {
+	str = stringVarPattern.ReplaceAllStringFunc(str, func(m string) string {
+		varName, text, isVar := stringVar(m)
+		if !isVar {
+			return text
 		}
 
 		v, ok := env.Get(varName)
@@ -84,6 +90,33 @@ func InterpolateStringVars(str string, env *object.Environment) string {
 	return str
 }
 
+// StringVars yazıyı InterpolateStringVars'ın değerlerini yerine koyacağı
+// adlara böler: parts[i] ile parts[i+1] arasına names[i]'nin değeri gelir
+func StringVars(str string) (parts []string, names []string) {
+	var part strings.Builder
+	last := 0
+
+	for _, loc := range stringVarPattern.FindAllStringIndex(str, -1) {
+		part.WriteString(str[last:loc[0]])
+		last = loc[1]
+
+		name, text, isVar := stringVar(str[loc[0]:loc[1]])
+		if !isVar {
+			part.WriteString(text)
+			continue
+		}
+
+		parts = append(parts, part.String())
+		names = append(names, name)
+		part.Reset()
+	}
+
+	part.WriteString(str[last:])
+	parts = append(parts, part.String())
+
+	return parts, names
+}
+
 func UniqueStrings(slice []string) []string {
 	keys := make(map[string]bool)
 	list := []string{}
@@ -0,0 +1,538 @@
+package vm
+
+import (
+	"strings"
+
+	"github.com/ankalang/anka/code"
+	"github.com/ankalang/anka/evaluator"
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+func isError(obj object.Object) bool {
+	switch obj.(type) {
+	case *object.Error, *object.BreakError, *object.ContinueError:
+		return true
+	}
+
+	return false
+}
+
+func nativeBool(b bool) object.Object {
+	if b {
+		return object.TRUE
+	}
+
+	return object.FALSE
+}
+
+func u16(ins code.Instructions, ip int) int {
+	return int(ins[ip])<<8 | int(ins[ip+1])
+}
+
+func u32(ins code.Instructions, ip int) int {
+	return int(code.ReadUint32(ins[ip:]))
+}
+
+// run en üstteki çerçeveyi ve onun çağırdıklarını çalıştırır; en alttaki
+// çerçeve döndüğünde dönüş değerini verir
+func (ex *execution) run() object.Object {
+	vm := ex.vm
+
+	var (
+		f      *frame
+		fn     *object.CompiledFunction
+		ins    code.Instructions
+		tokens []token.Token
+		base   int
+		ip     int
+	)
+
+	load := func() {
+		f = &ex.frames[len(ex.frames)-1]
+		fn = f.cl.Fn
+		ins = fn.Instructions
+		tokens = fn.Tokens
+		base = f.base
+		ip = f.ip
+	}
+
+	load()
+
+	for {
+		op := code.Opcode(ins[ip])
+		var result object.Object
+
+		switch op {
+		case code.OpConstant:
+			ex.push(vm.constants[u16(ins, ip+1)])
+			ip += 3
+			continue
+
+		case code.OpNull:
+			ex.push(object.NULL)
+			ip++
+			continue
+
+		case code.OpTrue:
+			ex.push(object.TRUE)
+			ip++
+			continue
+
+		case code.OpFalse:
+			ex.push(object.FALSE)
+			ip++
+			continue
+
+		case code.OpPop:
+			ex.sp--
+			ip++
+			continue
+
+		case code.OpGetGlobal:
+			result = evaluator.Lookup(tokens[u16(ins, ip+3)], vm.names[u16(ins, ip+1)], vm.env)
+			ip += 5
+
+		case code.OpSetGlobal:
+			if err := vm.env.Assign(vm.names[u16(ins, ip+1)], ex.pop()); err != nil {
+				result = evaluator.NewError(tokens[u16(ins, ip+3)], "%s", err.Error())
+				ip += 5
+				break
+			}
+
+			ip += 5
+			continue
+
+		case code.OpDeclareGlobal:
+			if err := vm.env.Declare(vm.names[u16(ins, ip+1)], ex.pop(), ins[ip+5] == 1); err != nil {
+				result = evaluator.NewError(tokens[u16(ins, ip+3)], "%s", err.Error())
+				ip += 6
+				break
+			}
+
+			ip += 6
+			continue
+
+		case code.OpGetLocal, code.OpGetCell, code.OpGetFree:
+			var value object.Object
+
+			idx := u16(ins, ip+1)
+			switch op {
+			case code.OpGetLocal:
+				value = ex.stack[base+idx]
+			case code.OpGetCell:
+				value = f.cells[idx].Get()
+			default:
+				value = f.cl.Free[idx].Get()
+			}
+
+			// değişken henüz atanmamışsa ağaç yorumlayıcısındaki gibi ortama bakılır
+			if value == nil {
+				result = evaluator.Lookup(tokens[u16(ins, ip+5)], vm.names[u16(ins, ip+3)], vm.env)
+				ip += 7
+				break
+			}
+
+			ex.push(value)
+			ip += 7
+			continue
+
+		case code.OpSetLocal:
+			ex.stack[base+u16(ins, ip+1)] = ex.pop()
+			ip += 3
+			continue
+
+		case code.OpSetCell:
+			f.cells[u16(ins, ip+1)].Set(ex.pop())
+			ip += 3
+			continue
+
+		case code.OpSetFree:
+			f.cl.Free[u16(ins, ip+1)].Set(ex.pop())
+			ip += 3
+			continue
+
+		case code.OpGetSoft:
+			var value object.Object
+
+			idx := u16(ins, ip+2)
+			switch int(ins[ip+1]) {
+			case 1:
+				value = ex.stack[base+idx]
+			case 2:
+				value = f.cells[idx].Get()
+			case 3:
+				value = f.cl.Free[idx].Get()
+			}
+
+			if value == nil {
+				value, _ = vm.env.Get(vm.names[u16(ins, ip+4)])
+			}
+
+			if value == nil {
+				value = &object.String{}
+			}
+
+			ex.push(value)
+			ip += 6
+			continue
+
+		case code.OpBox:
+			f.cells[u16(ins, ip+3)] = object.NewCell(ex.stack[base+u16(ins, ip+1)])
+			ip += 5
+			continue
+
+		case code.OpEnterBlock:
+			from, count := u16(ins, ip+1), u16(ins, ip+3)
+			for i := base + from; i < base+from+count; i++ {
+				ex.stack[i] = nil
+			}
+
+			from, count = u16(ins, ip+5), u16(ins, ip+7)
+			for i := from; i < from+count; i++ {
+				f.cells[i] = &object.Cell{}
+			}
+
+			ip += 9
+			continue
+
+		case code.OpClosure:
+			closure := vm.functions[u16(ins, ip+1)]
+			free := make([]*object.Cell, len(closure.Free))
+
+			for i, v := range closure.Free {
+				if v.Cell {
+					free[i] = f.cells[v.Index]
+				} else {
+					free[i] = f.cl.Free[v.Index]
+				}
+			}
+
+			ex.push(&object.Closure{Fn: closure, Free: free, Machine: vm})
+			ip += 3
+			continue
+
+		case code.OpCall, code.OpTailCall:
+			nargs, kw, tok := u16(ins, ip+1), u16(ins, ip+3), tokens[u16(ins, ip+5)]
+			ip += 7
+
+			keywords := ex.keywords(fn, kw)
+			nargs = ex.spreadCurrentArgs(ex.sp-nargs, nargs)
+			args := ex.sp - nargs
+			callee := ex.stack[args-1]
+
+			if cl, ok := callee.(*object.Closure); ok && cl.Machine == vm {
+				if op == code.OpTailCall && len(ex.handlers) == f.handlers {
+					// çağrılan, çağıranın yığın yerine taşınır ve çerçevesini alır
+					ex.endIterators(f.iterators)
+					copy(ex.stack[base-1:], ex.stack[args-1:ex.sp])
+					ex.sp = base + nargs
+					ex.args = ex.args[:f.args]
+					ex.frames = ex.frames[:len(ex.frames)-1]
+
+					if err := ex.enter(tok, cl, base, nargs, keywords); err != nil {
+						if len(ex.frames) == 0 {
+							return err
+						}
+
+						result = err
+						load()
+						break
+					}
+
+					load()
+					continue
+				}
+
+				f.ip = ip
+				if err := ex.enter(tok, cl, args, nargs, keywords); err != nil {
+					result = err
+					break
+				}
+
+				load()
+				continue
+			}
+
+			values := make([]object.Object, nargs)
+			copy(values, ex.stack[args:ex.sp])
+			ex.sp = args - 1
+
+			result = evaluator.Call(tok, callee, ex.callEnv(), values, keywords)
+
+		case code.OpMethod:
+			name, nargs, kw, tok := vm.names[u16(ins, ip+1)], u16(ins, ip+3), u16(ins, ip+5), tokens[u16(ins, ip+7)]
+			optional := ins[ip+9] == 1
+			ip += 10
+
+			keywords := ex.keywords(fn, kw)
+			args := make([]object.Object, nargs)
+			copy(args, ex.stack[ex.sp-nargs:ex.sp])
+			ex.sp -= nargs
+
+			result = evaluator.CallMethod(tok, ex.pop(), name, optional, ex.callEnv(), args, keywords)
+
+		case code.OpReturnValue:
+			value := ex.pop()
+			ex.leave()
+
+			if len(ex.frames) == 0 {
+				return value
+			}
+
+			ex.push(value)
+			load()
+			continue
+
+		case code.OpJump:
+			ip = u32(ins, ip+1)
+			continue
+
+		case code.OpJumpNotTruthy:
+			if !evaluator.IsTruthy(ex.pop()) {
+				ip = u32(ins, ip+1)
+				continue
+			}
+
+			ip += 5
+			continue
+
+		case code.OpJumpTruthy, code.OpJumpFalsy:
+			if evaluator.IsTruthy(ex.stack[ex.sp-1]) == (op == code.OpJumpTruthy) {
+				ip = u32(ins, ip+1)
+				continue
+			}
+
+			ex.sp--
+			ip += 5
+			continue
+
+		case code.OpPrefix:
+			result = evaluator.Prefix(tokens[u16(ins, ip+3)], vm.names[u16(ins, ip+1)], ex.pop())
+			ip += 5
+
+		case code.OpInfix:
+			right := ex.pop()
+			left := ex.pop()
+			result = evaluator.Infix(tokens[u16(ins, ip+3)], vm.names[u16(ins, ip+1)], left, right)
+			ip += 5
+
+		case code.OpArray:
+			n, tok := u16(ins, ip+1), tokens[u16(ins, ip+3)]
+			ip += 5
+
+			var elements []object.Object
+			if n > 0 {
+				elements = make([]object.Object, n)
+				copy(elements, ex.stack[ex.sp-n:ex.sp])
+				ex.sp -= n
+			}
+
+			result = &object.Array{Token: tok, Elements: elements}
+
+		case code.OpHash:
+			n, tok := u16(ins, ip+1), tokens[u16(ins, ip+3)]
+			ip += 5
+
+			hash := &object.Hash{Token: tok}
+			items := ex.stack[ex.sp-2*n : ex.sp]
+			ex.sp -= 2 * n
+			result = hash
+
+			for i := 0; i < len(items); i += 2 {
+				if _, ok := items[i].(object.Hashable); !ok {
+					result = evaluator.NewError(tok, "Harita anahtarı olarak %s kullanılamaz", items[i].Type())
+					break
+				}
+
+				hash.Set(items[i], items[i+1])
+			}
+
+		case code.OpIndex:
+			index := ex.pop()
+			left := ex.pop()
+			result = evaluator.Index(tokens[u16(ins, ip+1)], left, index, object.NULL, false)
+			ip += 3
+
+		case code.OpSlice:
+			end := ex.pop()
+			index := ex.pop()
+			left := ex.pop()
+			result = evaluator.Index(tokens[u16(ins, ip+1)], left, index, end, true)
+			ip += 3
+
+		case code.OpSetIndex:
+			index := ex.pop()
+			left := ex.pop()
+			value := ex.pop()
+			ip += 3
+
+			if res := evaluator.SetIndex(tokens[u16(ins, ip-2)], left, index, value); isError(res) {
+				result = res
+				break
+			}
+
+			continue
+
+		case code.OpProperty:
+			result = evaluator.Property(tokens[u16(ins, ip+3)], ex.pop(), vm.names[u16(ins, ip+1)], ins[ip+5] == 1)
+			ip += 6
+
+		case code.OpSetProperty:
+			o := ex.pop()
+			value := ex.pop()
+			ip += 5
+
+			if res := evaluator.SetProperty(tokens[u16(ins, ip-2)], o, vm.names[u16(ins, ip-4)], value); isError(res) {
+				result = res
+				break
+			}
+
+			continue
+
+		case code.OpInterpolate:
+			n, tok := u16(ins, ip+1), tokens[u16(ins, ip+3)]
+			ip += 5
+
+			var out strings.Builder
+			for _, part := range ex.stack[ex.sp-n : ex.sp] {
+				out.WriteString(part.Inspect())
+			}
+
+			ex.sp -= n
+			result = &object.String{Token: tok, Value: out.String()}
+
+		case code.OpCommand:
+			result = evaluator.Command(tokens[u16(ins, ip+1)], ex.pop().Inspect())
+			ip += 3
+
+		case code.OpDefault:
+			if ex.stack[base+u16(ins, ip+1)] != nil {
+				ip = u32(ins, ip+3)
+				continue
+			}
+
+			ip += 7
+			continue
+
+		case code.OpIterStart:
+			tok := tokens[u16(ins, ip+1)]
+			ip += 3
+
+			next, reset, err := evaluator.Iterate(tok, ex.pop(), ex.callEnv())
+			if err != nil {
+				result = err
+				break
+			}
+
+			ex.iterators = append(ex.iterators, iterator{next: next, reset: reset})
+			continue
+
+		case code.OpIterNext:
+			it := &ex.iterators[len(ex.iterators)-1]
+
+			k, v := it.next()
+			if err, ok := v.(*object.Error); ok && v != object.EOF {
+				result = err
+				break
+			}
+
+			if k == nil || v == object.EOF {
+				ip = u32(ins, ip+1)
+				continue
+			}
+
+			it.count++
+			ex.push(k)
+			ex.push(v)
+			ip += 5
+			continue
+
+		case code.OpIterEmpty:
+			ex.push(nativeBool(ex.iterators[len(ex.iterators)-1].count == 0))
+			ip++
+			continue
+
+		case code.OpIterEnd:
+			ex.endIterators(len(ex.iterators) - 1)
+			ip++
+			continue
+
+		case code.OpTry:
+			ex.handlers = append(ex.handlers, handler{
+				frame:     len(ex.frames) - 1,
+				ip:        u32(ins, ip+1),
+				sp:        ex.sp,
+				iterators: len(ex.iterators),
+			})
+			ip += 5
+			continue
+
+		case code.OpEndTry:
+			ex.handlers = ex.handlers[:len(ex.handlers)-1]
+			ip++
+			continue
+
+		case code.OpRaise:
+			result = ex.pop()
+			ip++
+
+		case code.OpErrorHash:
+			ex.push(evaluator.ErrorToHash(ex.pop().(*object.Error)))
+			ip++
+			continue
+
+		case code.OpFail:
+			result = evaluator.NewError(tokens[u16(ins, ip+3)], "%s", vm.names[u16(ins, ip+1)])
+			ip += 5
+
+		case code.OpEval:
+			result = evaluator.Eval(vm.nodes[u16(ins, ip+1)], vm.env)
+			ip += 3
+
+			// dosya düzeyindeki bir dön programı bitirir; ağaç yorumlayıcısında
+			// olduğu gibi yalnızca hatalar programı durdurur
+			switch res := result.(type) {
+			case nil:
+				result = object.NULL
+			case *object.ReturnValue:
+				ex.leave()
+				return res.Value
+			case *object.Error:
+			default:
+				ex.push(result)
+				continue
+			}
+		}
+
+		if !isError(result) {
+			ex.push(result)
+			continue
+		}
+
+		f.ip = ip
+		if !ex.throw(result) {
+			return result
+		}
+
+		load()
+	}
+}
+
+// keywords çağrının isimli argüman değerlerini yığından alır
+func (ex *execution) keywords(fn *object.CompiledFunction, kw int) []object.KeywordArgument {
+	if kw == 0 {
+		return nil
+	}
+
+	names := fn.Keywords[kw-1]
+	keywords := make([]object.KeywordArgument, len(names))
+	values := ex.stack[ex.sp-len(names) : ex.sp]
+
+	for i, k := range names {
+		keywords[i] = object.KeywordArgument{Token: k.Token, Name: k.Name, Value: values[i]}
+	}
+
+	ex.sp -= len(names)
+	return keywords
+}
@@ -0,0 +1,410 @@
+package vm
+
+import (
+	"sync"
+
+	"github.com/ankalang/anka/ast"
+	"github.com/ankalang/anka/compiler"
+	"github.com/ankalang/anka/evaluator"
+	"github.com/ankalang/anka/object"
+	"github.com/ankalang/anka/token"
+)
+
+const initialStackSize = 256
+
+// VM derlenmiş bir programı çalıştırır. Dosya değişkenleri ağaç
+// yorumlayıcısıyla aynı ortamda tutulur; böylece derlenemeyen ifadeler,
+// modüller ve yerleşik fonksiyonlar aynı değişkenleri görür.
+type VM struct {
+	env       *object.Environment
+	constants []object.Object
+	functions []*object.CompiledFunction
+	names     []string
+	nodes     []ast.Node
+	deferred  []ast.Node
+	main      *object.Closure
+
+	// her kapanış çağrısı kendi yığınıyla çalışır
+	pool sync.Pool
+}
+
+func New(bytecode *compiler.Bytecode, env *object.Environment) *VM {
+	vm := &VM{
+		env:       env,
+		constants: bytecode.Constants,
+		functions: bytecode.Functions,
+		names:     bytecode.Names,
+		nodes:     bytecode.Nodes,
+		deferred:  bytecode.Deferred,
+	}
+
+	vm.main = &object.Closure{Fn: bytecode.Main, Machine: vm}
+	vm.pool.New = func() interface{} {
+		return &execution{vm: vm, stack: make([]object.Object, initialStackSize)}
+	}
+
+	return vm
+}
+
+// Run programı çalıştırır, ardından ertelenmiş ifadeleri çalıştırır
+func (vm *VM) Run() object.Object {
+	// dosya düzeyi 0. derinliktedir, çağıranı yoktur
+	result := vm.run(token.Token{}, vm.main, -1, nil, nil)
+
+	for _, node := range vm.deferred {
+		evaluator.Eval(node, vm.env)
+	}
+
+	return result
+}
+
+// RunClosure kapanışı çalıştırıp sonucunu döner; yakalanmayan hatalar sonuç
+// olarak döner. Yerleşik fonksiyonların geri çağırdığı kapanışlar yeni bir
+// yığında çalışır, çağrı derinliği ise env'deki çağrıdan devam eder.
+func (vm *VM) RunClosure(tok token.Token, cl *object.Closure, env *object.Environment, args []object.Object, keywords []object.KeywordArgument) object.Object {
+	depth := 0
+	if env != nil {
+		if call := env.ActiveCall(); call != nil {
+			depth = call.Depth
+		}
+	}
+
+	return vm.run(tok, cl, depth, args, keywords)
+}
+
+func (vm *VM) run(tok token.Token, cl *object.Closure, depth int, args []object.Object, keywords []object.KeywordArgument) object.Object {
+	ex := vm.pool.Get().(*execution)
+	defer vm.release(ex)
+
+	ex.limit = evaluator.MaxCallDepth(vm.env)
+	ex.depth = depth
+
+	// çağrılan fonksiyonun yeri; dönüş değeri buraya yazılır
+	ex.push(cl)
+	base := ex.sp
+
+	for _, arg := range args {
+		ex.push(arg)
+	}
+
+	if err := ex.enter(tok, cl, base, len(args), keywords); err != nil {
+		return err
+	}
+
+	return ex.run()
+}
+
+func (vm *VM) release(ex *execution) {
+	for i := range ex.stack {
+		ex.stack[i] = nil
+	}
+
+	for i := range ex.args {
+		ex.args[i] = nil
+	}
+
+	ex.sp = 0
+	ex.args = ex.args[:0]
+	ex.frames = ex.frames[:0]
+	ex.handlers = ex.handlers[:0]
+	ex.iterators = ex.iterators[:0]
+
+	vm.pool.Put(ex)
+}
+
+type frame struct {
+	cl *object.Closure
+	// çağrının yapıldığı yer
+	tok   token.Token
+	ip    int
+	base  int
+	cells []*object.Cell
+	// çerçeveye girildiğindeki işleyici ve dolaşım sayıları
+	handlers  int
+	iterators int
+	// çağrının argümanları ex.args[args:args+nargs] aralığındadır
+	args  int
+	nargs int
+}
+
+// handler dene bloğunun hata işleyicisidir; hata bu çerçeveye, yığının bu
+// yüksekliğine dönülerek işlenir
+type handler struct {
+	frame     int
+	ip        int
+	sp        int
+	iterators int
+}
+
+type iterator struct {
+	next  func() (object.Object, object.Object)
+	reset func()
+	count int
+}
+
+type execution struct {
+	vm        *VM
+	stack     []object.Object
+	sp        int
+	frames    []frame
+	handlers  []handler
+	iterators []iterator
+	// çerçevelerin aldığı argümanlar; ağaç yorumlayıcısından gelen ... dizileri
+	// çağrıda bunlarla değiştirilir
+	args []object.Object
+	// iç içe en fazla çağrı sayısı; 0 sınırsızdır
+	limit int
+	// ilk çerçeveyi çağıranın derinliği
+	depth int
+}
+
+func (ex *execution) push(obj object.Object) {
+	if ex.sp == len(ex.stack) {
+		ex.grow(1)
+	}
+
+	ex.stack[ex.sp] = obj
+	ex.sp++
+}
+
+func (ex *execution) pop() object.Object {
+	ex.sp--
+	return ex.stack[ex.sp]
+}
+
+// grow yığında en az n boş yer olmasını sağlar
+func (ex *execution) grow(n int) {
+	if ex.sp+n <= len(ex.stack) {
+		return
+	}
+
+	size := 2 * len(ex.stack)
+	for size < ex.sp+n {
+		size *= 2
+	}
+
+	stack := make([]object.Object, size)
+	copy(stack, ex.stack[:ex.sp])
+	ex.stack = stack
+}
+
+// enter kapanış için yeni bir çerçeve açar; argümanlar base'den itibaren
+// yığındadır
+func (ex *execution) enter(tok token.Token, cl *object.Closure, base int, nargs int, keywords []object.KeywordArgument) *object.Error {
+	fn := cl.Fn
+	var params []*ast.Parameter
+	if fn.Node != nil {
+		params = fn.Node.Parameters
+	}
+
+	if ex.limit > 0 && ex.depth+len(ex.frames)+1 > ex.limit {
+		ex.sp = base - 1
+		return evaluator.CallDepthError(tok, ex.limit)
+	}
+
+	from := len(ex.args)
+	ex.args = append(ex.args, ex.stack[base:base+nargs]...)
+	ex.grow(fn.NumLocals - nargs)
+
+	if len(keywords) > 0 || nargs > len(params) || hasRest(params) {
+		if err := ex.bindArguments(fn, params, base, nargs, keywords); err != nil {
+			ex.sp = base - 1
+			ex.args = ex.args[:from]
+			return err
+		}
+	} else {
+		for i := base + nargs; i < base+fn.NumLocals; i++ {
+			ex.stack[i] = nil
+		}
+	}
+
+	for i := nargs; i < len(params); i++ {
+		p := params[i]
+		if ex.stack[base+i] == nil && !p.Rest && p.Default == nil {
+			ex.sp = base - 1
+			ex.args = ex.args[:from]
+			return evaluator.NewError(fn.Node.Token, "%s fonksiyonu için %s argümanı bulunamadı.", functionName(fn), p.Value)
+		}
+	}
+
+	var cells []*object.Cell
+	if fn.NumCells > 0 {
+		cells = make([]*object.Cell, fn.NumCells)
+		for i := range cells {
+			cells[i] = &object.Cell{}
+		}
+	}
+
+	ex.frames = append(ex.frames, frame{
+		cl:        cl,
+		tok:       tok,
+		base:      base,
+		cells:     cells,
+		handlers:  len(ex.handlers),
+		iterators: len(ex.iterators),
+		args:      from,
+		nargs:     nargs,
+	})
+	ex.sp = base + fn.NumLocals
+
+	return nil
+}
+
+// callEnv yerleşik fonksiyonlara verilen ortamdır; çağrı derinliğini taşır ki
+// geri çağrılan kapanışlar ANK_CALL_DEPTH sınırını aşamasın
+func (ex *execution) callEnv() *object.Environment {
+	depth := ex.depth + len(ex.frames)
+	if depth == 0 {
+		return ex.vm.env
+	}
+
+	f := &ex.frames[len(ex.frames)-1]
+	return object.NewCallEnvironment(ex.vm.env, &object.Call{Function: functionName(f.cl.Fn), Token: f.tok, Depth: depth})
+}
+
+func hasRest(params []*ast.Parameter) bool {
+	return len(params) > 0 && params[len(params)-1].Rest
+}
+
+// bindArguments kalan ve isimli argümanları parametre yuvalarına yerleştirir
+func (ex *execution) bindArguments(fn *object.CompiledFunction, params []*ast.Parameter, base int, nargs int, keywords []object.KeywordArgument) *object.Error {
+	args := make([]object.Object, nargs)
+	copy(args, ex.stack[base:base+nargs])
+
+	for i := base; i < base+fn.NumLocals; i++ {
+		ex.stack[i] = nil
+	}
+
+	for _, k := range keywords {
+		idx := parameterIndex(params, k.Name)
+		if idx == -1 {
+			return evaluator.NewError(k.Token, "%s fonksiyonunun '%s' adında bir parametresi yok", functionName(fn), k.Name)
+		}
+
+		if idx < nargs {
+			return evaluator.NewError(k.Token, "%s fonksiyonunun '%s' parametresine hem sıralı hem isimli argüman verildi", functionName(fn), k.Name)
+		}
+
+		ex.stack[base+idx] = k.Value
+	}
+
+	for i, p := range params {
+		if p.Rest {
+			rest := []object.Object{}
+			if nargs > i {
+				rest = append(rest, args[i:]...)
+			}
+
+			ex.stack[base+i] = &object.Array{Token: p.Token, Elements: rest}
+			continue
+		}
+
+		if i < nargs {
+			ex.stack[base+i] = args[i]
+		}
+	}
+
+	return nil
+}
+
+func parameterIndex(params []*ast.Parameter, name string) int {
+	for i, p := range params {
+		if !p.Rest && p.Pattern == nil && p.Value == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func functionName(fn *object.CompiledFunction) string {
+	if fn.Node == nil || fn.Node.Name == "" {
+		return "isimsiz"
+	}
+
+	return fn.Node.Name
+}
+
+// leave çerçeveden çıkar; çerçevede açık kalan dolaşımlar sıfırlanır
+func (ex *execution) leave() {
+	f := &ex.frames[len(ex.frames)-1]
+
+	ex.endIterators(f.iterators)
+	ex.handlers = ex.handlers[:f.handlers]
+	ex.args = ex.args[:f.args]
+	ex.sp = f.base - 1
+	ex.frames = ex.frames[:len(ex.frames)-1]
+}
+
+// spreadCurrentArgs ilk argüman ağaç yorumlayıcısının ürettiği bir ... dizisiyse
+// onu çalışan çerçevenin argümanlarıyla değiştirir; argümanlar args'tan
+// başlayarak yığındadır, yeni argüman sayısını döner
+func (ex *execution) spreadCurrentArgs(args int, nargs int) int {
+	if nargs == 0 {
+		return nargs
+	}
+
+	if first, ok := ex.stack[args].(*object.Array); !ok || !first.IsCurrentArgs {
+		return nargs
+	}
+
+	f := &ex.frames[len(ex.frames)-1]
+	current := ex.args[f.args : f.args+f.nargs]
+	rest := append([]object.Object(nil), ex.stack[args+1:ex.sp]...)
+
+	ex.sp = args
+	ex.grow(len(current) + len(rest))
+	for _, arg := range current {
+		ex.push(arg)
+	}
+	for _, arg := range rest {
+		ex.push(arg)
+	}
+
+	return len(current) + len(rest)
+}
+
+// unwind hatanın çıktığı çerçeveyi kapatır
+func (ex *execution) unwind(err *object.Error) {
+	f := &ex.frames[len(ex.frames)-1]
+	if err != nil && f.cl.Fn.Node != nil {
+		err.Stack = append(err.Stack, evaluator.NewFrame(functionName(f.cl.Fn), f.tok))
+	}
+
+	ex.leave()
+}
+
+func (ex *execution) endIterators(n int) {
+	for i := len(ex.iterators) - 1; i >= n; i-- {
+		ex.iterators[i].reset()
+	}
+
+	ex.iterators = ex.iterators[:n]
+}
+
+// throw hatayı en yakın dene bloğuna iletir; işleyici yoksa false döner.
+// Hatanın çıktığı çerçeveler hatanın yığınına eklenir.
+func (ex *execution) throw(err object.Object) bool {
+	e, ok := err.(*object.Error)
+	if !ok || len(ex.handlers) == 0 {
+		for len(ex.frames) > 0 {
+			ex.unwind(e)
+		}
+
+		return false
+	}
+
+	h := ex.handlers[len(ex.handlers)-1]
+	for len(ex.frames)-1 > h.frame {
+		ex.unwind(e)
+	}
+
+	ex.handlers = ex.handlers[:len(ex.handlers)-1]
+
+	ex.endIterators(h.iterators)
+	ex.sp = h.sp
+	ex.push(err)
+	ex.frames[h.frame].ip = h.ip
+
+	return true
+}